@@ -0,0 +1,115 @@
+// Package otellogrus bridge logrus logger to open telemetry log provider
+package otellogrus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/erry-az/otel-go/logbridge"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/log"
+)
+
+// default hook setting
+const nameDefault = "github.com/erry-az/otel-go/bridge/otellogrus"
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// HookOption option for logrus hook
+type HookOption struct {
+	// LoggerProvider log provider to emit the record, global log provider is used when nil
+	LoggerProvider log.LoggerProvider
+	// Name instrumentation scope name of the logger (default: github.com/erry-az/otel-go/bridge/otellogrus)
+	Name string
+	// Levels level to emit (default: all level)
+	Levels []logrus.Level
+}
+
+// Hook logrus hook that emit every entry as open telemetry log record,
+// entry data is converted to attribute and the span context of entry context is used for trace correlation.
+// the log provider is flushed after panic and fatal entry
+type Hook struct {
+	provider log.LoggerProvider
+	logger   log.Logger
+	levels   []logrus.Level
+}
+
+// NewHook create logrus hook
+//
+//	logrus.AddHook(otellogrus.NewHook(otellogrus.HookOption{LoggerProvider: providers.LogProvider}))
+//	logrus.WithContext(ctx).WithField("user.id", "123").Info("hello")
+func NewHook(opt HookOption) *Hook {
+	if opt.Name == "" {
+		opt.Name = nameDefault
+	}
+
+	if len(opt.Levels) == 0 {
+		opt.Levels = logrus.AllLevels
+	}
+
+	return &Hook{
+		provider: opt.LoggerProvider,
+		logger:   logbridge.Logger(opt.LoggerProvider, opt.Name),
+		levels:   opt.Levels,
+	}
+}
+
+// Levels returns level to emit
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire emit the entry as log record
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !logbridge.Enabled(ctx, h.logger, severity(entry.Level)) {
+		return nil
+	}
+
+	var record log.Record
+
+	record.SetTimestamp(entry.Time)
+	record.SetBody(log.StringValue(entry.Message))
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(strings.ToUpper(entry.Level.String()))
+	record.AddAttributes(logbridge.KeyValues(entry.Data)...)
+
+	if entry.Caller != nil {
+		record.AddAttributes(logbridge.CodeAttributes(entry.Caller.File, entry.Caller.Line, entry.Caller.Function)...)
+	}
+
+	h.logger.Emit(ctx, record)
+
+	// panic and fatal entry is about to terminate the process, the entry context can be canceled already
+	if entry.Level <= logrus.FatalLevel {
+		return logbridge.ForceFlush(context.Background(), h.provider)
+	}
+
+	return nil
+}
+
+// severity map logrus level to log severity, panic is mapped to higher fatal severity
+func severity(level logrus.Level) log.Severity {
+	switch level {
+	case logrus.TraceLevel:
+		return log.SeverityTrace
+	case logrus.DebugLevel:
+		return log.SeverityDebug
+	case logrus.InfoLevel:
+		return log.SeverityInfo
+	case logrus.WarnLevel:
+		return log.SeverityWarn
+	case logrus.ErrorLevel:
+		return log.SeverityError
+	case logrus.FatalLevel:
+		return log.SeverityFatal
+	case logrus.PanicLevel:
+		return log.SeverityFatal2
+	}
+
+	return log.SeverityUndefined
+}
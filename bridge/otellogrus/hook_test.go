@@ -0,0 +1,196 @@
+package otellogrus
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// memoryExporter log exporter that keep the exported record in memory
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func newTestLogger(opt HookOption) (*logrus.Logger, *memoryExporter) {
+	exporter := &memoryExporter{}
+	opt.LoggerProvider = sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(NewHook(opt))
+
+	return logger, exporter
+}
+
+func attributes(record sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	return attrs
+}
+
+func TestHookSeverity(t *testing.T) {
+	tests := []struct {
+		level        logrus.Level
+		severity     log.Severity
+		severityText string
+	}{
+		{level: logrus.TraceLevel, severity: log.SeverityTrace, severityText: "TRACE"},
+		{level: logrus.DebugLevel, severity: log.SeverityDebug, severityText: "DEBUG"},
+		{level: logrus.InfoLevel, severity: log.SeverityInfo, severityText: "INFO"},
+		{level: logrus.WarnLevel, severity: log.SeverityWarn, severityText: "WARNING"},
+		{level: logrus.ErrorLevel, severity: log.SeverityError, severityText: "ERROR"},
+		{level: logrus.FatalLevel, severity: log.SeverityFatal, severityText: "FATAL"},
+		{level: logrus.PanicLevel, severity: log.SeverityFatal2, severityText: "PANIC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			logger, exporter := newTestLogger(HookOption{})
+
+			entry := logrus.NewEntry(logger)
+			entry.Level = tt.level
+			entry.Message = "hello"
+			entry.Time = time.Unix(1700000000, 0)
+
+			err := logger.Hooks[tt.level][0].Fire(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			record := exporter.records[0]
+			if record.Severity() != tt.severity || record.SeverityText() != tt.severityText {
+				t.Errorf("got severity %v %q, want %v %q", record.Severity(), record.SeverityText(), tt.severity, tt.severityText)
+			}
+
+			if record.Body().AsString() != "hello" || !record.Timestamp().Equal(time.Unix(1700000000, 0)) {
+				t.Errorf("unexpected body %q or timestamp %v", record.Body().AsString(), record.Timestamp())
+			}
+		})
+	}
+}
+
+func TestHookLevels(t *testing.T) {
+	logger, exporter := newTestLogger(HookOption{Levels: []logrus.Level{logrus.ErrorLevel}})
+
+	logger.Info("skipped")
+	logger.Error("failed")
+
+	if len(exporter.records) != 1 || exporter.records[0].Body().AsString() != "failed" {
+		t.Errorf("got %d records, want error record only", len(exporter.records))
+	}
+}
+
+func TestHookField(t *testing.T) {
+	logger, exporter := newTestLogger(HookOption{})
+	logger.SetReportCaller(true)
+
+	logger.WithFields(logrus.Fields{
+		"service": "payments",
+		"amount":  1200,
+		"retry":   false,
+		"rate":    0.5,
+		"tags":    []string{"a", "b"},
+	}).Info("paid")
+
+	attrs := attributes(exporter.records[0])
+
+	if attrs["service"].AsString() != "payments" || attrs["amount"].AsInt64() != 1200 ||
+		attrs["retry"].AsBool() || attrs["rate"].AsFloat64() != 0.5 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	if tags := attrs["tags"].AsSlice(); len(tags) != 2 || tags[0].AsString() != "a" || tags[1].AsString() != "b" {
+		t.Errorf("unexpected tags attribute %v", attrs["tags"])
+	}
+
+	if attrs["code.filepath"].AsString() == "" || attrs["code.lineno"].AsInt64() == 0 || attrs["code.function"].AsString() == "" {
+		t.Errorf("caller attribute is not added: %v", attrs)
+	}
+}
+
+func TestHookTraceCorrelation(t *testing.T) {
+	logger, exporter := newTestLogger(HookOption{})
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	logger.WithContext(trace.ContextWithSpanContext(context.Background(), spanContext)).Info("with context")
+	logger.Info("without context")
+
+	record := exporter.records[0]
+	if record.TraceID() != spanContext.TraceID() || record.SpanID() != spanContext.SpanID() || record.TraceFlags() != trace.FlagsSampled {
+		t.Errorf("got trace %s span %s, want %s %s", record.TraceID(), record.SpanID(), spanContext.TraceID(), spanContext.SpanID())
+	}
+
+	if exporter.records[1].TraceID().IsValid() {
+		t.Errorf("record without context got trace %s", exporter.records[1].TraceID())
+	}
+}
+
+func TestHookFlush(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour))))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(NewHook(HookOption{LoggerProvider: provider}))
+
+	exported := func() int {
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+
+		return len(exporter.records)
+	}
+
+	logger.Error("error")
+
+	if got := exported(); got != 0 {
+		t.Errorf("got %d exported record after error entry, want 0", got)
+	}
+
+	func() {
+		defer func() { _ = recover() }()
+
+		logger.Panic("panic")
+	}()
+
+	if got := exported(); got != 2 {
+		t.Errorf("got %d exported record after panic entry, want 2", got)
+	}
+}
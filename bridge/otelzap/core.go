@@ -0,0 +1,175 @@
+// Package otelzap bridge zap logger to open telemetry log provider
+package otelzap
+
+import (
+	"context"
+
+	"github.com/erry-az/otel-go/logbridge"
+	"go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// default core setting
+const (
+	nameDefault = "github.com/erry-az/otel-go/bridge/otelzap"
+	// contextKey field key of the context field, the field is not encoded by other core
+	contextKey = "context"
+	// loggerNameKey attribute key of the zap logger name
+	loggerNameKey = "logger.name"
+)
+
+var _ zapcore.Core = (*Core)(nil)
+
+// CoreOption option for zap core
+type CoreOption struct {
+	// LoggerProvider log provider to emit the record, global log provider is used when nil
+	LoggerProvider log.LoggerProvider
+	// Name instrumentation scope name of the logger (default: github.com/erry-az/otel-go/bridge/otelzap)
+	Name string
+}
+
+// Core zap core that emit every entry as open telemetry log record,
+// field is converted to attribute and the span context of Context field is used for trace correlation.
+// the log provider is flushed on Sync and after dpanic, panic and fatal entry like zap io core
+type Core struct {
+	provider log.LoggerProvider
+	logger   log.Logger
+	ctx      context.Context
+	fields   []zapcore.Field
+}
+
+// NewCore create zap core, combine with existing core to keep the current output
+//
+//	logger := zap.New(zapcore.NewTee(zapLogger.Core(), otelzap.NewCore(otelzap.CoreOption{
+//		LoggerProvider: providers.LogProvider,
+//	})))
+//	logger.Info("hello", otelzap.Context(ctx), zap.String("user.id", "123"))
+func NewCore(opt CoreOption) *Core {
+	if opt.Name == "" {
+		opt.Name = nameDefault
+	}
+
+	return &Core{
+		provider: opt.LoggerProvider,
+		logger:   logbridge.Logger(opt.LoggerProvider, opt.Name),
+		ctx:      context.Background(),
+	}
+}
+
+// Context returns field that carry the context for trace correlation, the field is skipped by other core
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// Enabled returns whether the log provider emit record with the level severity
+func (c *Core) Enabled(level zapcore.Level) bool {
+	return logbridge.Enabled(c.ctx, c.logger, severity(level))
+}
+
+// With returns core with the fields added to every record
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.ctx, fields = extractContext(c.ctx, fields)
+	clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+
+	return &clone
+}
+
+// Check add the core when the entry level is enabled
+func (c *Core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+// Write emit the entry as log record
+func (c *Core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ctx, fields := extractContext(c.ctx, fields)
+
+	var record log.Record
+
+	record.SetTimestamp(entry.Time)
+	record.SetBody(log.StringValue(entry.Message))
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(entry.Level.CapitalString())
+
+	encoder := zapcore.NewMapObjectEncoder()
+
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	record.AddAttributes(logbridge.KeyValues(encoder.Fields)...)
+
+	if entry.LoggerName != "" {
+		record.AddAttributes(log.String(loggerNameKey, entry.LoggerName))
+	}
+
+	if entry.Caller.Defined {
+		record.AddAttributes(logbridge.CodeAttributes(entry.Caller.File, entry.Caller.Line, entry.Caller.Function)...)
+	}
+
+	if entry.Stack != "" {
+		record.AddAttributes(log.String(string(semconv.CodeStacktraceKey), entry.Stack))
+	}
+
+	c.logger.Emit(ctx, record)
+
+	// dpanic, panic and fatal entry is about to terminate the process
+	if entry.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
+	return nil
+}
+
+// Sync flush the log provider when it implement ForceFlush, e.g. sdklog.LoggerProvider
+func (c *Core) Sync() error {
+	return logbridge.ForceFlush(context.Background(), c.provider)
+}
+
+// extractContext returns the last context field and the fields without context field
+func extractContext(ctx context.Context, fields []zapcore.Field) (context.Context, []zapcore.Field) {
+	filtered := fields[:0:0]
+
+	for _, field := range fields {
+		if fieldCtx, ok := field.Interface.(context.Context); ok && field.Key == contextKey && field.Type == zapcore.SkipType {
+			ctx = fieldCtx
+			continue
+		}
+
+		filtered = append(filtered, field)
+	}
+
+	return ctx, filtered
+}
+
+// severity map zap level to log severity, dpanic, panic and fatal is mapped to fatal severity
+func severity(level zapcore.Level) log.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return log.SeverityDebug
+	case zapcore.InfoLevel:
+		return log.SeverityInfo
+	case zapcore.WarnLevel:
+		return log.SeverityWarn
+	case zapcore.ErrorLevel:
+		return log.SeverityError
+	case zapcore.DPanicLevel:
+		return log.SeverityFatal1
+	case zapcore.PanicLevel:
+		return log.SeverityFatal2
+	case zapcore.FatalLevel:
+		return log.SeverityFatal3
+	}
+
+	return log.SeverityUndefined
+}
@@ -0,0 +1,193 @@
+package otelzap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// memoryExporter log exporter that keep the exported record in memory
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func newTestCore() (*Core, *memoryExporter) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	return NewCore(CoreOption{LoggerProvider: provider}), exporter
+}
+
+func attributes(record sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	return attrs
+}
+
+func TestCoreSeverity(t *testing.T) {
+	tests := []struct {
+		level        zapcore.Level
+		severity     log.Severity
+		severityText string
+	}{
+		{level: zapcore.DebugLevel, severity: log.SeverityDebug, severityText: "DEBUG"},
+		{level: zapcore.InfoLevel, severity: log.SeverityInfo, severityText: "INFO"},
+		{level: zapcore.WarnLevel, severity: log.SeverityWarn, severityText: "WARN"},
+		{level: zapcore.ErrorLevel, severity: log.SeverityError, severityText: "ERROR"},
+		{level: zapcore.DPanicLevel, severity: log.SeverityFatal1, severityText: "DPANIC"},
+		{level: zapcore.PanicLevel, severity: log.SeverityFatal2, severityText: "PANIC"},
+		{level: zapcore.FatalLevel, severity: log.SeverityFatal3, severityText: "FATAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			core, exporter := newTestCore()
+
+			err := core.Write(zapcore.Entry{Level: tt.level, Message: "hello", Time: time.Unix(1700000000, 0)}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			record := exporter.records[0]
+			if record.Severity() != tt.severity || record.SeverityText() != tt.severityText {
+				t.Errorf("got severity %v %q, want %v %q", record.Severity(), record.SeverityText(), tt.severity, tt.severityText)
+			}
+
+			if record.Body().AsString() != "hello" || !record.Timestamp().Equal(time.Unix(1700000000, 0)) {
+				t.Errorf("unexpected body %q or timestamp %v", record.Body().AsString(), record.Timestamp())
+			}
+		})
+	}
+}
+
+func TestCoreField(t *testing.T) {
+	core, exporter := newTestCore()
+
+	logger := zap.New(core).Named("payment").With(zap.String("service", "payments"))
+	logger.Info("paid",
+		zap.Int("amount", 1200),
+		zap.Bool("retry", false),
+		zap.Float64("rate", 0.5),
+		zap.Strings("tags", []string{"a", "b"}),
+	)
+
+	attrs := attributes(exporter.records[0])
+
+	if attrs["service"].AsString() != "payments" || attrs["amount"].AsInt64() != 1200 ||
+		attrs["retry"].AsBool() || attrs["rate"].AsFloat64() != 0.5 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	if tags := attrs["tags"].AsSlice(); len(tags) != 2 || tags[0].AsString() != "a" || tags[1].AsString() != "b" {
+		t.Errorf("unexpected tags attribute %v", attrs["tags"])
+	}
+
+	if attrs[loggerNameKey].AsString() != "payment" {
+		t.Errorf("got logger name %v, want payment", attrs[loggerNameKey])
+	}
+}
+
+func TestCoreTraceCorrelation(t *testing.T) {
+	core, exporter := newTestCore()
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	logger := zap.New(core)
+	logger.Info("with field", Context(ctx))
+	logger.With(Context(ctx)).Info("with logger")
+	logger.Info("without context")
+
+	for i, record := range exporter.records[:2] {
+		if record.TraceID() != spanContext.TraceID() || record.SpanID() != spanContext.SpanID() || record.TraceFlags() != trace.FlagsSampled {
+			t.Errorf("record %d got trace %s span %s, want %s %s", i, record.TraceID(), record.SpanID(), spanContext.TraceID(), spanContext.SpanID())
+		}
+
+		if _, ok := attributes(record)[contextKey]; ok {
+			t.Errorf("record %d context field is added as attribute", i)
+		}
+	}
+
+	if exporter.records[2].TraceID().IsValid() {
+		t.Errorf("record without context got trace %s", exporter.records[2].TraceID())
+	}
+}
+
+func TestCoreFlush(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour))))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	core := NewCore(CoreOption{LoggerProvider: provider})
+
+	exported := func() int {
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+
+		return len(exporter.records)
+	}
+
+	if err := core.Write(zapcore.Entry{Level: zapcore.ErrorLevel, Message: "error"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := exported(); got != 0 {
+		t.Errorf("got %d exported record after error entry, want 0", got)
+	}
+
+	// fatal entry terminate the process after the write
+	if err := core.Write(zapcore.Entry{Level: zapcore.FatalLevel, Message: "fatal"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := exported(); got != 2 {
+		t.Errorf("got %d exported record after fatal entry, want 2", got)
+	}
+
+	if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "info"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := core.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := exported(); got != 3 {
+		t.Errorf("got %d exported record after sync, want 3", got)
+	}
+}
@@ -0,0 +1,25 @@
+module github.com/erry-az/otel-go/bridge/otelzap
+
+go 1.22.7
+
+require (
+	github.com/erry-az/otel-go v0.0.0-20261019072635-789e95009d26
+	go.opentelemetry.io/otel v1.32.0
+	go.opentelemetry.io/otel/log v0.8.0
+	go.opentelemetry.io/otel/sdk/log v0.8.0
+	go.opentelemetry.io/otel/trace v1.32.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.32.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+)
+
+// build with the root module of this repository, replace is ignored when the bridge is required by other module
+replace github.com/erry-az/otel-go => ../..
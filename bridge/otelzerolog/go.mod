@@ -0,0 +1,26 @@
+module github.com/erry-az/otel-go/bridge/otelzerolog
+
+go 1.22.7
+
+require (
+	github.com/erry-az/otel-go v0.0.0-20261019072635-789e95009d26
+	github.com/rs/zerolog v1.33.0
+	go.opentelemetry.io/otel v1.32.0
+	go.opentelemetry.io/otel/log v0.8.0
+	go.opentelemetry.io/otel/sdk/log v0.8.0
+	go.opentelemetry.io/otel/trace v1.32.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.32.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+)
+
+// build with the root module of this repository, replace is ignored when the bridge is required by other module
+replace github.com/erry-az/otel-go => ../..
@@ -0,0 +1,255 @@
+// Package otelzerolog bridge zerolog logger to open telemetry log provider
+package otelzerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erry-az/otel-go/logbridge"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// default writer and hook setting
+const (
+	nameDefault          = "github.com/erry-az/otel-go/bridge/otelzerolog"
+	traceIDKeyDefault    = "trace_id"
+	spanIDKeyDefault     = "span_id"
+	traceFlagsKeyDefault = "trace_flags"
+)
+
+var (
+	_ zerolog.LevelWriter = (*Writer)(nil)
+	_ zerolog.Hook        = (*Hook)(nil)
+)
+
+// TraceFieldOption field name of the span context added by Hook and read by Writer
+type TraceFieldOption struct {
+	// TraceIDKey field name for trace id (default: trace_id)
+	TraceIDKey string
+	// SpanIDKey field name for span id (default: span_id)
+	SpanIDKey string
+	// TraceFlagsKey field name for trace flags (default: trace_flags)
+	TraceFlagsKey string
+}
+
+// WriterOption option for zerolog writer
+type WriterOption struct {
+	// LoggerProvider log provider to emit the record, global log provider is used when nil
+	LoggerProvider log.LoggerProvider
+	// Name instrumentation scope name of the logger (default: github.com/erry-az/otel-go/bridge/otelzerolog)
+	Name string
+	// TraceField field name of the span context used for trace correlation
+	TraceField TraceFieldOption
+}
+
+// Writer zerolog writer that decode every json event and emit it as open telemetry log record,
+// the span context fields added by Hook is used for trace correlation and removed from the attributes.
+// the log provider is flushed after panic and fatal event
+type Writer struct {
+	provider log.LoggerProvider
+	logger   log.Logger
+	field    TraceFieldOption
+}
+
+// Hook zerolog hook that add span context of the event context as fields
+type Hook struct {
+	field TraceFieldOption
+}
+
+// NewWriter create zerolog writer, combine with existing writer to keep the current output
+//
+//	logger := zerolog.New(zerolog.MultiLevelWriter(os.Stdout, otelzerolog.NewWriter(otelzerolog.WriterOption{
+//		LoggerProvider: providers.LogProvider,
+//	}))).Hook(otelzerolog.NewHook(otelzerolog.TraceFieldOption{}))
+//	logger.Info().Ctx(ctx).Str("user.id", "123").Msg("hello")
+func NewWriter(opt WriterOption) *Writer {
+	if opt.Name == "" {
+		opt.Name = nameDefault
+	}
+
+	return &Writer{
+		provider: opt.LoggerProvider,
+		logger:   logbridge.Logger(opt.LoggerProvider, opt.Name),
+		field:    opt.TraceField.withDefault(),
+	}
+}
+
+// NewHook create hook that add trace id, span id and trace flags of the event context,
+// the fields only added when the context has valid span context
+func NewHook(opt TraceFieldOption) *Hook {
+	return &Hook{field: opt.withDefault()}
+}
+
+// Run add the span context fields to the event
+func (h *Hook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+
+	e.Str(h.field.TraceIDKey, spanContext.TraceID().String()).
+		Str(h.field.SpanIDKey, spanContext.SpanID().String()).
+		Str(h.field.TraceFlagsKey, spanContext.TraceFlags().String())
+}
+
+// Write emit the event, the level is read from the level field
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel emit the event with the level, non json event is skipped and handled by otel error handler
+// instead of returning error that make zerolog print it on every event
+func (w *Writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level == zerolog.Disabled {
+		return len(p), nil
+	}
+
+	var fields map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&fields)
+	if err != nil {
+		otel.Handle(fmt.Errorf("otelzerolog: decode event: %w", err))
+		return len(p), nil
+	}
+
+	if level == zerolog.NoLevel {
+		if levelField, ok := fields[zerolog.LevelFieldName].(string); ok {
+			level, _ = zerolog.ParseLevel(levelField)
+		}
+	}
+
+	if !logbridge.Enabled(context.Background(), w.logger, severity(level)) {
+		return len(p), nil
+	}
+
+	var record log.Record
+
+	record.SetSeverity(severity(level))
+	record.SetSeverityText(strings.ToUpper(level.String()))
+
+	if message, ok := fields[zerolog.MessageFieldName].(string); ok {
+		record.SetBody(log.StringValue(message))
+	}
+
+	if timestamp, ok := parseTime(fields[zerolog.TimestampFieldName]); ok {
+		record.SetTimestamp(timestamp)
+	}
+
+	if caller, ok := fields[zerolog.CallerFieldName].(string); ok {
+		// caller is "file:line", the file can contain colon on windows
+		if i := strings.LastIndex(caller, ":"); i > 0 {
+			line, _ := strconv.Atoi(caller[i+1:])
+			record.AddAttributes(logbridge.CodeAttributes(caller[:i], line, "")...)
+			delete(fields, zerolog.CallerFieldName)
+		}
+	}
+
+	ctx := w.spanContext(fields)
+
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+
+	record.AddAttributes(logbridge.KeyValues(fields)...)
+	w.logger.Emit(ctx, record)
+
+	// panic and fatal event is about to terminate the process
+	if level == zerolog.FatalLevel || level == zerolog.PanicLevel {
+		return len(p), logbridge.ForceFlush(context.Background(), w.provider)
+	}
+
+	return len(p), nil
+}
+
+// spanContext returns context with span context from the trace fields and remove the fields
+func (w *Writer) spanContext(fields map[string]any) context.Context {
+	traceID, _ := fields[w.field.TraceIDKey].(string)
+	spanID, _ := fields[w.field.SpanIDKey].(string)
+	traceFlags, _ := fields[w.field.TraceFlagsKey].(string)
+
+	if traceID == "" || spanID == "" {
+		return context.Background()
+	}
+
+	delete(fields, w.field.TraceIDKey)
+	delete(fields, w.field.SpanIDKey)
+	delete(fields, w.field.TraceFlagsKey)
+
+	return logbridge.ContextWithSpanContext(context.Background(), traceID, spanID, traceFlags == trace.FlagsSampled.String())
+}
+
+func (o TraceFieldOption) withDefault() TraceFieldOption {
+	if o.TraceIDKey == "" {
+		o.TraceIDKey = traceIDKeyDefault
+	}
+
+	if o.SpanIDKey == "" {
+		o.SpanIDKey = spanIDKeyDefault
+	}
+
+	if o.TraceFlagsKey == "" {
+		o.TraceFlagsKey = traceFlagsKeyDefault
+	}
+
+	return o
+}
+
+// parseTime parse time field with zerolog.TimeFieldFormat
+func parseTime(value any) (time.Time, bool) {
+	switch value := value.(type) {
+	case string:
+		timestamp, err := time.Parse(zerolog.TimeFieldFormat, value)
+		return timestamp, err == nil
+	case json.Number:
+		unix, err := value.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		switch zerolog.TimeFieldFormat {
+		case zerolog.TimeFormatUnix:
+			return time.Unix(unix, 0), true
+		case zerolog.TimeFormatUnixMs:
+			return time.UnixMilli(unix), true
+		case zerolog.TimeFormatUnixMicro:
+			return time.UnixMicro(unix), true
+		case zerolog.TimeFormatUnixNano:
+			return time.Unix(0, unix), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// severity map zerolog level to log severity, no level is undefined severity
+func severity(level zerolog.Level) log.Severity {
+	switch level {
+	case zerolog.TraceLevel:
+		return log.SeverityTrace
+	case zerolog.DebugLevel:
+		return log.SeverityDebug
+	case zerolog.InfoLevel:
+		return log.SeverityInfo
+	case zerolog.WarnLevel:
+		return log.SeverityWarn
+	case zerolog.ErrorLevel:
+		return log.SeverityError
+	case zerolog.FatalLevel:
+		return log.SeverityFatal
+	case zerolog.PanicLevel:
+		return log.SeverityFatal2
+	}
+
+	return log.SeverityUndefined
+}
@@ -0,0 +1,237 @@
+package otelzerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// memoryExporter log exporter that keep the exported record in memory
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func newTestWriter() (*Writer, *memoryExporter) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	return NewWriter(WriterOption{LoggerProvider: provider}), exporter
+}
+
+func attributes(record sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	return attrs
+}
+
+func TestWriterSeverity(t *testing.T) {
+	tests := []struct {
+		level        zerolog.Level
+		severity     log.Severity
+		severityText string
+	}{
+		{level: zerolog.TraceLevel, severity: log.SeverityTrace, severityText: "TRACE"},
+		{level: zerolog.DebugLevel, severity: log.SeverityDebug, severityText: "DEBUG"},
+		{level: zerolog.InfoLevel, severity: log.SeverityInfo, severityText: "INFO"},
+		{level: zerolog.WarnLevel, severity: log.SeverityWarn, severityText: "WARN"},
+		{level: zerolog.ErrorLevel, severity: log.SeverityError, severityText: "ERROR"},
+		{level: zerolog.FatalLevel, severity: log.SeverityFatal, severityText: "FATAL"},
+		{level: zerolog.PanicLevel, severity: log.SeverityFatal2, severityText: "PANIC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			writer, exporter := newTestWriter()
+
+			_, err := writer.WriteLevel(tt.level, []byte(`{"message":"hello"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// level field is read when the level is not passed
+			_, err = writer.Write([]byte(`{"level":"` + tt.level.String() + `","message":"hello"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, record := range exporter.records {
+				if record.Severity() != tt.severity || record.SeverityText() != tt.severityText {
+					t.Errorf("got severity %v %q, want %v %q", record.Severity(), record.SeverityText(), tt.severity, tt.severityText)
+				}
+
+				if record.Body().AsString() != "hello" {
+					t.Errorf("got body %q, want hello", record.Body().AsString())
+				}
+			}
+		})
+	}
+}
+
+func TestWriterField(t *testing.T) {
+	writer, exporter := newTestWriter()
+
+	logger := zerolog.New(writer).With().Timestamp().Str("service", "payments").Logger()
+	logger.Info().
+		Int("amount", 1200).
+		Bool("retry", false).
+		Float64("rate", 0.5).
+		Strs("tags", []string{"a", "b"}).
+		Dict("user", zerolog.Dict().Str("id", "123")).
+		Msg("paid")
+
+	record := exporter.records[0]
+	attrs := attributes(record)
+
+	if attrs["service"].AsString() != "payments" || attrs["amount"].AsInt64() != 1200 ||
+		attrs["retry"].AsBool() || attrs["rate"].AsFloat64() != 0.5 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	if tags := attrs["tags"].AsSlice(); len(tags) != 2 || tags[0].AsString() != "a" || tags[1].AsString() != "b" {
+		t.Errorf("unexpected tags attribute %v", attrs["tags"])
+	}
+
+	if user := attrs["user"].AsMap(); len(user) != 1 || user[0].Key != "id" || user[0].Value.AsString() != "123" {
+		t.Errorf("unexpected user attribute %v", attrs["user"])
+	}
+
+	for _, key := range []string{zerolog.MessageFieldName, zerolog.LevelFieldName, zerolog.TimestampFieldName} {
+		if _, ok := attrs[key]; ok {
+			t.Errorf("field %s is added as attribute", key)
+		}
+	}
+
+	if time.Since(record.Timestamp()) > time.Minute {
+		t.Errorf("timestamp field is not read, got %v", record.Timestamp())
+	}
+}
+
+func TestWriterTraceCorrelation(t *testing.T) {
+	writer, exporter := newTestWriter()
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	var output bytes.Buffer
+
+	logger := zerolog.New(zerolog.MultiLevelWriter(&output, writer)).Hook(NewHook(TraceFieldOption{}))
+	logger.Info().Ctx(ctx).Msg("with context")
+	logger.Info().Msg("without context")
+
+	record := exporter.records[0]
+	if record.TraceID() != spanContext.TraceID() || record.SpanID() != spanContext.SpanID() || record.TraceFlags() != trace.FlagsSampled {
+		t.Errorf("got trace %s span %s, want %s %s", record.TraceID(), record.SpanID(), spanContext.TraceID(), spanContext.SpanID())
+	}
+
+	attrs := attributes(record)
+	for _, key := range []string{traceIDKeyDefault, spanIDKeyDefault, traceFlagsKeyDefault} {
+		if _, ok := attrs[key]; ok {
+			t.Errorf("trace field %s is added as attribute", key)
+		}
+	}
+
+	if !bytes.Contains(output.Bytes(), []byte(`"trace_id":"0102030405060708090a0b0c0d0e0f10"`)) {
+		t.Errorf("trace field is not written to the other writer: %s", output.String())
+	}
+
+	if exporter.records[1].TraceID().IsValid() {
+		t.Errorf("record without context got trace %s", exporter.records[1].TraceID())
+	}
+}
+
+func TestWriterNonJSON(t *testing.T) {
+	var handled []error
+
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		handled = append(handled, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(previous) })
+
+	writer, exporter := newTestWriter()
+
+	p := []byte("plain text\n")
+
+	n, err := writer.WriteLevel(zerolog.InfoLevel, p)
+	if err != nil || n != len(p) {
+		t.Errorf("got %d, %v, want %d, nil", n, err, len(p))
+	}
+
+	var syntaxErr *json.SyntaxError
+	if len(handled) != 1 || !errors.As(handled[0], &syntaxErr) {
+		t.Errorf("got handled error %v, want decode error", handled)
+	}
+
+	if len(exporter.records) != 0 {
+		t.Errorf("got %d records, want none", len(exporter.records))
+	}
+}
+
+func TestWriterFlush(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour))))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	logger := zerolog.New(NewWriter(WriterOption{LoggerProvider: provider}))
+
+	exported := func() int {
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+
+		return len(exporter.records)
+	}
+
+	logger.Error().Msg("error")
+
+	if got := exported(); got != 0 {
+		t.Errorf("got %d exported record after error event, want 0", got)
+	}
+
+	func() {
+		defer func() { _ = recover() }()
+
+		logger.Panic().Msg("panic")
+	}()
+
+	if got := exported(); got != 2 {
+		t.Errorf("got %d exported record after panic event, want 2", got)
+	}
+}
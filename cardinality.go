@@ -0,0 +1,264 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// default cardinality guard setting
+const (
+	cardinalityWindowDefault             = time.Minute
+	cardinalityMaxSpanNamesDefault       = 1000
+	cardinalityMaxAttributeValuesDefault = 100
+)
+
+// placeholder for normalized span name segment and capped attribute value
+const (
+	cardinalityIDPlaceholder    = "{id}"
+	cardinalityUUIDPlaceholder  = "{uuid}"
+	cardinalityOtherPlaceholder = "_other"
+)
+
+// cardinality violation metric
+const (
+	cardinalityViolationMetric        = "otelgo.span.cardinality.violations"
+	cardinalityViolationTypeKey       = attribute.Key("otelgo.cardinality.violation.type")
+	cardinalityViolationAttributeKey  = attribute.Key("otelgo.cardinality.attribute.key")
+	cardinalityViolationTypeSpanName  = "span_name"
+	cardinalityViolationTypeAttribute = "attribute"
+)
+
+// cardinalitySpanNameWarnKey warned key for span name violation, can't collide with attribute key
+const cardinalitySpanNameWarnKey = "\x00span_name"
+
+var (
+	cardinalityNumericSegment = regexp.MustCompile(`^[0-9]+$`)
+	cardinalityUUIDSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+var _ sdktrace.SpanProcessor = (*CardinalityGuardProcessor)(nil)
+
+// CardinalityGuardOption option for cardinality guard span processor
+type CardinalityGuardOption struct {
+	// Window sliding window duration for distinct count (default: 1m)
+	Window time.Duration
+	// MaxSpanNames maximum distinct span name in the window before reported as violation (default: 1000)
+	MaxSpanNames int
+	// MaxAttributeValues maximum distinct value per guarded attribute key in the window (default: 100)
+	// value over the limit is replaced with "_other"
+	MaxAttributeValues int
+	// AttributeKeys string attribute keys to guard, empty will only guard the span name
+	AttributeKeys []string
+	// MeterProvider meter provider for violation metric (default: global meter provider)
+	MeterProvider metric.MeterProvider
+}
+
+// CardinalityGuardProcessor span processor that normalise numeric and uuid path segment of the span name,
+// cap distinct value of the configured attribute keys and report high cardinality span name and attribute value.
+// the guard run on span start so only span name and attributes set when the span started are guarded
+type CardinalityGuardProcessor struct {
+	opt       CardinalityGuardOption
+	guardKeys map[attribute.Key]bool
+
+	mu         sync.Mutex
+	spanNames  *slidingDistinct
+	attributes map[attribute.Key]*slidingDistinct
+	warned     map[string]bool
+
+	violations metric.Int64Counter
+}
+
+// NewCardinalityGuardProcessor create cardinality guard span processor
+// register it before other processor so they see the normalised span, for example
+//
+//	otel.NewTraceProvider(res, exporter, sdktrace.WithSpanProcessor(otel.NewCardinalityGuardProcessor(otel.CardinalityGuardOption{})))
+func NewCardinalityGuardProcessor(opt CardinalityGuardOption) *CardinalityGuardProcessor {
+	if opt.Window <= 0 {
+		opt.Window = cardinalityWindowDefault
+	}
+
+	if opt.MaxSpanNames <= 0 {
+		opt.MaxSpanNames = cardinalityMaxSpanNamesDefault
+	}
+
+	if opt.MaxAttributeValues <= 0 {
+		opt.MaxAttributeValues = cardinalityMaxAttributeValuesDefault
+	}
+
+	if opt.MeterProvider == nil {
+		opt.MeterProvider = otel.GetMeterProvider()
+	}
+
+	guardKeys := make(map[attribute.Key]bool, len(opt.AttributeKeys))
+	for _, key := range opt.AttributeKeys {
+		guardKeys[attribute.Key(key)] = true
+	}
+
+	violations, err := opt.MeterProvider.Meter(instrumentationName).Int64Counter(cardinalityViolationMetric,
+		metric.WithDescription("Number of span name and attribute value over the cardinality limit"),
+		metric.WithUnit("{violation}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &CardinalityGuardProcessor{
+		opt:        opt,
+		guardKeys:  guardKeys,
+		spanNames:  newSlidingDistinct(opt.Window, opt.MaxSpanNames),
+		attributes: make(map[attribute.Key]*slidingDistinct),
+		warned:     make(map[string]bool),
+		violations: violations,
+	}
+}
+
+// OnStart normalise the span name and cap the attribute value
+func (p *CardinalityGuardProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	name := NormalizeSpanName(span.Name())
+	if name != span.Name() {
+		span.SetName(name)
+	}
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.spanNames.add(name, now) {
+		p.report(ctx, cardinalitySpanNameWarnKey, cardinalityViolationTypeSpanName, "",
+			"span name "+name+", avoid putting id in the span name")
+	}
+
+	var capped []attribute.KeyValue
+
+	for _, attr := range span.Attributes() {
+		if attr.Value.Type() != attribute.STRING || !p.guardKeys[attr.Key] {
+			continue
+		}
+
+		values, ok := p.attributes[attr.Key]
+		if !ok {
+			values = newSlidingDistinct(p.opt.Window, p.opt.MaxAttributeValues)
+			p.attributes[attr.Key] = values
+		}
+
+		if !values.add(attr.Value.AsString(), now) {
+			capped = append(capped, attr.Key.String(cardinalityOtherPlaceholder))
+			p.report(ctx, string(attr.Key), cardinalityViolationTypeAttribute, string(attr.Key),
+				"attribute "+string(attr.Key)+", value replaced with "+cardinalityOtherPlaceholder)
+		}
+	}
+
+	if len(capped) > 0 {
+		span.SetAttributes(capped...)
+	}
+}
+
+// OnEnd do nothing
+func (p *CardinalityGuardProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown do nothing
+func (p *CardinalityGuardProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush do nothing
+func (p *CardinalityGuardProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// report count the violation and send ErrSpanCardinality to otel.Handle once per span name or attribute key
+func (p *CardinalityGuardProcessor) report(ctx context.Context, warnKey, violationType, attributeKey, detail string) {
+	if p.violations != nil {
+		attrs := []attribute.KeyValue{cardinalityViolationTypeKey.String(violationType)}
+		if attributeKey != "" {
+			attrs = append(attrs, cardinalityViolationAttributeKey.String(attributeKey))
+		}
+
+		p.violations.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	if p.warned[warnKey] {
+		return
+	}
+
+	p.warned[warnKey] = true
+	otel.Handle(fmt.Errorf("%w: %s", ErrSpanCardinality, detail))
+}
+
+// NormalizeSpanName replace numeric and uuid path segment of the span name with placeholder
+// e.g. "GET /users/123/orders/0b6a1a8e-3b5c-4b8e-9d8f-2a1c1f0e9a7b" to "GET /users/{id}/orders/{uuid}"
+func NormalizeSpanName(name string) string {
+	if !strings.Contains(name, "/") {
+		return name
+	}
+
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		switch {
+		case cardinalityNumericSegment.MatchString(segment):
+			segments[i] = cardinalityIDPlaceholder
+		case cardinalityUUIDSegment.MatchString(segment):
+			segments[i] = cardinalityUUIDPlaceholder
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// slidingDistinct count distinct value seen in the sliding window, limited to max value
+type slidingDistinct struct {
+	window     time.Duration
+	max        int
+	seen       map[string]time.Time
+	lastExpire time.Time
+}
+
+func newSlidingDistinct(window time.Duration, max int) *slidingDistinct {
+	return &slidingDistinct{
+		window: window,
+		max:    max,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// add the value to the window, returns false when the value is new and the window already reach the max
+func (s *slidingDistinct) add(value string, now time.Time) bool {
+	if _, ok := s.seen[value]; ok {
+		s.seen[value] = now
+		return true
+	}
+
+	// expire at most every tenth of the window so full window don't scan on every value
+	if len(s.seen) >= s.max && now.Sub(s.lastExpire) > s.window/10 {
+		s.expire(now)
+	}
+
+	if len(s.seen) >= s.max {
+		return false
+	}
+
+	s.seen[value] = now
+
+	return true
+}
+
+// expire remove the value not seen in the window
+func (s *slidingDistinct) expire(now time.Time) {
+	s.lastExpire = now
+
+	for value, lastSeen := range s.seen {
+		if now.Sub(lastSeen) > s.window {
+			delete(s.seen, value)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCardinalityGuardProcessor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewCardinalityGuardProcessor(CardinalityGuardOption{
+			MaxAttributeValues: 2,
+			AttributeKeys:      []string{"user.id"},
+		})),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	tracer := provider.Tracer("test")
+
+	for i := 0; i < 3; i++ {
+		_, span := tracer.Start(context.Background(), "GET /users/"+strconv.Itoa(i), trace.WithAttributes(
+			attribute.String("user.id", strconv.Itoa(i)),
+			attribute.String("http.route", strconv.Itoa(i)),
+		))
+		span.End()
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	for i, span := range spans {
+		if span.Name() != "GET /users/{id}" {
+			t.Errorf("span %d got name %q, want normalized name", i, span.Name())
+		}
+
+		attrs := attribute.NewSet(span.Attributes()...)
+
+		wantUser := strconv.Itoa(i)
+		if i == 2 {
+			wantUser = cardinalityOtherPlaceholder
+		}
+
+		if value, _ := attrs.Value("user.id"); value.AsString() != wantUser {
+			t.Errorf("span %d got user.id %q, want %q", i, value.AsString(), wantUser)
+		}
+
+		// attribute that is not configured is not capped
+		if value, _ := attrs.Value("http.route"); value.AsString() != strconv.Itoa(i) {
+			t.Errorf("span %d got http.route %q, want %q", i, value.AsString(), strconv.Itoa(i))
+		}
+	}
+}
+
+func TestNormalizeSpanName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "GET /users/123", want: "GET /users/{id}"},
+		{name: "GET /users/123/orders/0b6a1a8e-3b5c-4b8e-9d8f-2a1c1f0e9a7b", want: "GET /users/{id}/orders/{uuid}"},
+		{name: "GET /users/0B6A1A8E-3B5C-4B8E-9D8F-2A1C1F0E9A7B/", want: "GET /users/{uuid}/"},
+		{name: "GET /v2/users/abc123", want: "GET /v2/users/abc123"},
+		{name: "GET /users/12.5", want: "GET /users/12.5"},
+		{name: "GET /users/0b6a1a8e-3b5c-4b8e-9d8f", want: "GET /users/0b6a1a8e-3b5c-4b8e-9d8f"},
+		{name: "process 123", want: "process 123"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeSpanName(tt.name); got != tt.want {
+			t.Errorf("got %q from %q, want %q", got, tt.name, tt.want)
+		}
+	}
+}
+
+func TestSlidingDistinct(t *testing.T) {
+	var (
+		now    = time.Now()
+		window = time.Minute
+		values = newSlidingDistinct(window, 2)
+	)
+
+	if !values.add("a", now) || !values.add("b", now) {
+		t.Fatal("got value under the max rejected")
+	}
+
+	if values.add("c", now.Add(time.Second)) {
+		t.Error("got new value over the max added")
+	}
+
+	// seen value is always accepted and its last seen is refreshed
+	if !values.add("a", now.Add(window/2)) {
+		t.Error("got seen value rejected")
+	}
+
+	// b is expired after the window, a is still in the window
+	if !values.add("c", now.Add(window+time.Second)) {
+		t.Error("got new value rejected after the window")
+	}
+
+	if _, ok := values.seen["b"]; ok {
+		t.Error("got expired value kept")
+	}
+
+	if values.add("d", now.Add(window+2*time.Second)) {
+		t.Error("got new value over the max added, a and c are in the window")
+	}
+}
+
+func TestCardinalityGuardProcessorViolation(t *testing.T) {
+	errs := captureTestErrors(t)
+	reader := sdkmetric.NewManualReader()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewCardinalityGuardProcessor(CardinalityGuardOption{
+		MaxSpanNames:       1,
+		MaxAttributeValues: 1,
+		AttributeKeys:      []string{"user.id"},
+		MeterProvider:      sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})))
+	tracer := provider.Tracer("test")
+
+	for _, name := range []string{"GET /users", "GET /orders", "GET /items"} {
+		_, span := tracer.Start(context.Background(), name, trace.WithAttributes(attribute.String("user.id", name)))
+		span.End()
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]int64)
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != cardinalityViolationMetric {
+				continue
+			}
+
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				violationType, _ := point.Attributes.Value(cardinalityViolationTypeKey)
+				attributeKey, _ := point.Attributes.Value(cardinalityViolationAttributeKey)
+				got[violationType.AsString()+"/"+attributeKey.AsString()] = point.Value
+			}
+		}
+	}
+
+	want := map[string]int64{
+		cardinalityViolationTypeSpanName + "/":         2,
+		cardinalityViolationTypeAttribute + "/user.id": 2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got violations %v, want %v", got, want)
+	}
+
+	// warned once per span name and attribute key
+	handled := errs()
+	if len(handled) != 2 {
+		t.Fatalf("got %d handled errors %v, want 2", len(handled), handled)
+	}
+
+	for _, err := range handled {
+		if !errors.Is(err, ErrSpanCardinality) {
+			t.Errorf("got error %v, want %v", err, ErrSpanCardinality)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package otel
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// for in-process channel propagation.MapCarrier (map[string]string) can be used directly
+var (
+	_ propagation.TextMapCarrier = (*SliceCarrier[Header])(nil)
+	_ propagation.TextMapCarrier = MultiMapCarrier{}
+	_ propagation.TextMapCarrier = AnyMapCarrier{}
+)
+
+// Header key value header with byte value, same shape as kafka record header
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// SliceCarrier carrier for slice of key value header like kafka record headers
+// the header type is generic so the library header type can be used directly, for example sarama.RecordHeader
+type SliceCarrier[H any] struct {
+	headers   *[]H
+	key       func(h H) string
+	value     func(h H) string
+	newHeader func(key, value string) H
+}
+
+// NewSliceCarrier create carrier for slice of header H
+// key and value used to read the header, newHeader used to create new header when context injected
+//
+//	carrier := otel.NewSliceCarrier(&msg.Headers,
+//		func(h sarama.RecordHeader) string { return string(h.Key) },
+//		func(h sarama.RecordHeader) string { return string(h.Value) },
+//		func(k, v string) sarama.RecordHeader { return sarama.RecordHeader{Key: []byte(k), Value: []byte(v)} },
+//	)
+func NewSliceCarrier[H any](headers *[]H, key, value func(h H) string, newHeader func(key, value string) H) *SliceCarrier[H] {
+	return &SliceCarrier[H]{
+		headers:   headers,
+		key:       key,
+		value:     value,
+		newHeader: newHeader,
+	}
+}
+
+// NewHeaderCarrier create carrier for slice of Header
+func NewHeaderCarrier(headers *[]Header) *SliceCarrier[Header] {
+	return NewSliceCarrier(headers,
+		func(h Header) string { return h.Key },
+		func(h Header) string { return string(h.Value) },
+		func(key, value string) Header { return Header{Key: key, Value: []byte(value)} },
+	)
+}
+
+// Get returns the value of the first header with the key
+func (c *SliceCarrier[H]) Get(key string) string {
+	for _, h := range *c.headers {
+		if c.key(h) == key {
+			return c.value(h)
+		}
+	}
+
+	return ""
+}
+
+// Set replace all header with the key by new header
+func (c *SliceCarrier[H]) Set(key, value string) {
+	headers := make([]H, 0, len(*c.headers)+1)
+	for _, h := range *c.headers {
+		if c.key(h) != key {
+			headers = append(headers, h)
+		}
+	}
+
+	*c.headers = append(headers, c.newHeader(key, value))
+}
+
+// Keys returns the keys of all header
+func (c *SliceCarrier[H]) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, c.key(h))
+	}
+
+	return keys
+}
+
+// MultiMapCarrier carrier for map[string][]string headers like nats.Header
+// unlike propagation.HeaderCarrier the key is not canonicalized
+type MultiMapCarrier map[string][]string
+
+// Get returns the first value of the key
+func (c MultiMapCarrier) Get(key string) string {
+	values := c[key]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Set replace the values of the key
+func (c MultiMapCarrier) Set(key, value string) {
+	c[key] = []string{value}
+}
+
+// Keys returns the keys of the map
+func (c MultiMapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// AnyMapCarrier carrier for map[string]any envelope like amqp.Table or json message envelope
+// value with type string and []byte will be read as is and other type will be formatted with fmt
+type AnyMapCarrier map[string]any
+
+// Get returns the value of the key as string
+func (c AnyMapCarrier) Get(key string) string {
+	switch value := c[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Set set the value of the key as string
+func (c AnyMapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys returns the keys of the map
+func (c AnyMapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,161 @@
+package otel
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testCarrierHeader header type that is not Header to test generic SliceCarrier
+type testCarrierHeader struct {
+	Key   []byte
+	Value []byte
+}
+
+func TestSliceCarrier(t *testing.T) {
+	headers := []testCarrierHeader{
+		{Key: []byte("traceparent"), Value: []byte("first")},
+		{Key: []byte("other"), Value: []byte("value")},
+		{Key: []byte("traceparent"), Value: []byte("second")},
+	}
+	carrier := NewSliceCarrier(&headers,
+		func(h testCarrierHeader) string { return string(h.Key) },
+		func(h testCarrierHeader) string { return string(h.Value) },
+		func(k, v string) testCarrierHeader { return testCarrierHeader{Key: []byte(k), Value: []byte(v)} },
+	)
+
+	if got := carrier.Get("traceparent"); got != "first" {
+		t.Errorf("got %q, want first header value", got)
+	}
+
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("got %q for missing key, want empty", got)
+	}
+
+	carrier.Set("traceparent", "replaced")
+
+	if len(headers) != 2 {
+		t.Fatalf("got %d headers, want duplicated key replaced by single header", len(headers))
+	}
+
+	if got := carrier.Get("traceparent"); got != "replaced" {
+		t.Errorf("got %q, want replaced", got)
+	}
+
+	if got, want := carrier.Keys(), []string{"other", "traceparent"}; !slices.Equal(got, want) {
+		t.Errorf("got keys %v, want %v", got, want)
+	}
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	var headers []Header
+	carrier := NewHeaderCarrier(&headers)
+
+	carrier.Set("tracestate", "a=b")
+
+	if len(headers) != 1 || headers[0].Key != "tracestate" || string(headers[0].Value) != "a=b" {
+		t.Errorf("got headers %+v, want single tracestate header", headers)
+	}
+
+	if got := carrier.Get("tracestate"); got != "a=b" {
+		t.Errorf("got %q, want a=b", got)
+	}
+}
+
+func TestMultiMapCarrier(t *testing.T) {
+	carrier := MultiMapCarrier{"traceparent": {"first", "second"}, "empty": {}}
+
+	if got := carrier.Get("traceparent"); got != "first" {
+		t.Errorf("got %q, want first value", got)
+	}
+
+	if got := carrier.Get("empty"); got != "" {
+		t.Errorf("got %q for empty values, want empty", got)
+	}
+
+	// key is not canonicalized like http header
+	carrier.Set("baggage", "k=v")
+
+	if got := carrier["baggage"]; !slices.Equal(got, []string{"k=v"}) {
+		t.Errorf("got %v, want value under the original key", got)
+	}
+
+	carrier.Set("traceparent", "replaced")
+
+	if got := carrier["traceparent"]; !slices.Equal(got, []string{"replaced"}) {
+		t.Errorf("got %v, want values replaced", got)
+	}
+
+	keys := carrier.Keys()
+	slices.Sort(keys)
+
+	if want := []string{"baggage", "empty", "traceparent"}; !slices.Equal(keys, want) {
+		t.Errorf("got keys %v, want %v", keys, want)
+	}
+}
+
+func TestAnyMapCarrier(t *testing.T) {
+	carrier := AnyMapCarrier{"string": "value", "bytes": []byte("bytes"), "int": 10, "nil": nil}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "string", want: "value"},
+		{key: "bytes", want: "bytes"},
+		{key: "int", want: "10"},
+		{key: "nil", want: ""},
+		{key: "missing", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := carrier.Get(tt.key); got != tt.want {
+			t.Errorf("key %s got %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	carrier.Set("traceparent", "value")
+
+	if got, ok := carrier["traceparent"].(string); !ok || got != "value" {
+		t.Errorf("got %#v, want string value", carrier["traceparent"])
+	}
+
+	keys := carrier.Keys()
+	slices.Sort(keys)
+
+	if want := []string{"bytes", "int", "nil", "string", "traceparent"}; !slices.Equal(keys, want) {
+		t.Errorf("got keys %v, want %v", keys, want)
+	}
+}
+
+func TestCarrierPropagation(t *testing.T) {
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	ctx := testSpanContext(true)
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	var headers []Header
+
+	tests := []struct {
+		name    string
+		carrier propagation.TextMapCarrier
+	}{
+		{name: "header", carrier: NewHeaderCarrier(&headers)},
+		{name: "multi map", carrier: MultiMapCarrier{}},
+		{name: "any map", carrier: AnyMapCarrier{}},
+		{name: "map", carrier: propagation.MapCarrier{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			propagator.Inject(ctx, tt.carrier)
+
+			got := trace.SpanContextFromContext(propagator.Extract(context.Background(), tt.carrier))
+			if got.TraceID() != spanContext.TraceID() || got.SpanID() != spanContext.SpanID() || !got.IsRemote() {
+				t.Errorf("got span context %+v, want remote %+v", got, spanContext)
+			}
+		})
+	}
+}
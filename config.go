@@ -1,8 +1,16 @@
 package otel
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 // environment for exporter type
@@ -14,9 +22,103 @@ const (
 	providersEnv          = "OTEL_PROVIDERS"
 )
 
+// environment for trace provider
+const (
+	traceIDGeneratorEnv = "OTEL_TRACES_ID_GENERATOR"
+)
+
+// environment for metric provider
+const (
+	metricViewsEnv     = "OTEL_METRICS_VIEWS"
+	metricViewsFileEnv = "OTEL_METRICS_VIEWS_FILE"
+	runtimeMetricsEnv  = "OTEL_GO_RUNTIME_METRICS"
+	seriesLimitEnv     = "OTEL_METRICS_SERIES_LIMIT"
+	bucketPresetsEnv   = "OTEL_METRICS_HISTOGRAM_BUCKET_PRESETS"
+)
+
+// environment for otlp metric exporter histogram aggregation
+const (
+	histogramAggregationEnv         = "OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION"
+	exponentialHistogramMaxSizeEnv  = "OTEL_EXPORTER_OTLP_METRICS_EXPONENTIAL_HISTOGRAM_MAX_SIZE"
+	exponentialHistogramMaxScaleEnv = "OTEL_EXPORTER_OTLP_METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE"
+)
+
+// environment for log provider
+const (
+	logMinSeverityEnv        = "OTEL_LOGS_MIN_SEVERITY"
+	logMinSeverityLoggersEnv = "OTEL_LOGS_MIN_SEVERITY_LOGGERS"
+	logDedupWindowEnv        = "OTEL_LOGS_DEDUP_WINDOW"
+	logDedupLimitEnv         = "OTEL_LOGS_DEDUP_LIMIT"
+	logSampledTracesOnlyEnv  = "OTEL_LOGS_SAMPLED_TRACES_ONLY"
+)
+
+// environment for log batch processor
+const (
+	logScheduleDelayEnv      = "OTEL_BLRP_SCHEDULE_DELAY"
+	logExportTimeoutEnv      = "OTEL_BLRP_EXPORT_TIMEOUT"
+	logMaxQueueSizeEnv       = "OTEL_BLRP_MAX_QUEUE_SIZE"
+	logMaxExportBatchSizeEnv = "OTEL_BLRP_MAX_EXPORT_BATCH_SIZE"
+)
+
+// environment for host metrics
+const (
+	hostMetricsEnv         = "OTEL_HOST_METRICS"
+	hostMetricsIntervalEnv = "OTEL_HOST_METRICS_INTERVAL"
+	hostMetricsProcRootEnv = "OTEL_HOST_METRICS_PROC_ROOT"
+)
+
+// environment for prometheus metrics server
+const (
+	prometheusHostEnv = "OTEL_EXPORTER_PROMETHEUS_HOST"
+	prometheusPortEnv = "OTEL_EXPORTER_PROMETHEUS_PORT"
+	prometheusPathEnv = "OTEL_EXPORTER_PROMETHEUS_PATH"
+)
+
+// environment for prometheus exporter
+const (
+	prometheusNamespaceEnv              = "OTEL_EXPORTER_PROMETHEUS_NAMESPACE"
+	prometheusWithoutUnitsEnv           = "OTEL_EXPORTER_PROMETHEUS_WITHOUT_UNITS"
+	prometheusWithoutCounterSuffixesEnv = "OTEL_EXPORTER_PROMETHEUS_WITHOUT_COUNTER_SUFFIXES"
+	prometheusWithoutScopeInfoEnv       = "OTEL_EXPORTER_PROMETHEUS_WITHOUT_SCOPE_INFO"
+	prometheusWithoutTargetInfoEnv      = "OTEL_EXPORTER_PROMETHEUS_WITHOUT_TARGET_INFO"
+)
+
+// environment for statsd exporter
+const (
+	statsdEndpointEnv = "OTEL_EXPORTER_STATSD_ENDPOINT"
+	statsdPrefixEnv   = "OTEL_EXPORTER_STATSD_PREFIX"
+)
+
+// environment for prometheus remote write exporter
+const (
+	remoteWriteEndpointEnv    = "OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_ENDPOINT"
+	remoteWriteHeadersEnv     = "OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_HEADERS"
+	remoteWriteUsernameEnv    = "OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_USERNAME"
+	remoteWritePasswordEnv    = "OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_PASSWORD"
+	remoteWriteBearerTokenEnv = "OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_BEARER_TOKEN"
+)
+
+// environment for syslog and journald log exporter
+const (
+	syslogEndpointEnv     = "OTEL_EXPORTER_SYSLOG_ENDPOINT"
+	syslogFacilityEnv     = "OTEL_EXPORTER_SYSLOG_FACILITY"
+	syslogAppNameEnv      = "OTEL_EXPORTER_SYSLOG_APP_NAME"
+	journaldEndpointEnv   = "OTEL_EXPORTER_JOURNALD_ENDPOINT"
+	journaldIdentifierEnv = "OTEL_EXPORTER_JOURNALD_IDENTIFIER"
+)
+
 // default env
 var (
-	providersEnvDefault = ProvidersEnable{Trace: true, Metric: true}
+	providersEnvDefault        = ProvidersEnable{Trace: true, Metric: true}
+	prometheusHostEnvDefault   = "localhost"
+	prometheusPortEnvDefault   = "9464"
+	prometheusPathEnvDefault   = "/metrics"
+	runtimeMetricsEnvDefault   = "true"
+	seriesLimitEnvDefault      = 2000
+	logScheduleDelayEnvDefault = time.Second
+	logExportTimeoutEnvDefault = 30 * time.Second
+	logMaxQueueSizeEnvDefault  = 2048
+	logMaxBatchSizeEnvDefault  = 512
 )
 
 func getTraceExporterTypeFromEnv() TraceExporterType {
@@ -36,6 +138,19 @@ func getTraceExporterTypeFromEnv() TraceExporterType {
 	return ""
 }
 
+func getTraceIDGeneratorTypeFromEnv() (IDGeneratorType, error) {
+	envIDGenerator := IDGeneratorType(os.Getenv(traceIDGeneratorEnv))
+
+	switch envIDGenerator {
+	case "":
+		return RandomIDGeneratorType, nil
+	case RandomIDGeneratorType, XRayIDGeneratorType:
+		return envIDGenerator, nil
+	}
+
+	return "", ErrInvalidIDGeneratorType
+}
+
 func getMetricExporterTypeFromEnv() MetricExporterType {
 	var (
 		envExporterType       = os.Getenv(exporterTypeEnv)
@@ -53,6 +168,374 @@ func getMetricExporterTypeFromEnv() MetricExporterType {
 	return ""
 }
 
+// getMetricViewsFromEnv read view configs from OTEL_METRICS_VIEWS json
+// and OTEL_METRICS_VIEWS_FILE json or yaml file, views from env is placed before views from file
+func getMetricViewsFromEnv() ([]MetricViewConfig, error) {
+	var views []MetricViewConfig
+
+	if envViews := os.Getenv(metricViewsEnv); envViews != "" {
+		err := json.Unmarshal([]byte(envViews), &views)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", metricViewsEnv, err)
+		}
+	}
+
+	if envViewsFile := os.Getenv(metricViewsFileEnv); envViewsFile != "" {
+		fileViews, err := ReadMetricViewsFile(envViewsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		views = append(views, fileViews...)
+	}
+
+	presetViews, err := getHistogramBucketPresetsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(views, presetViews...), nil
+}
+
+// getHistogramBucketPresetsFromEnv returns histogram view config from "instrument=preset,..." env
+func getHistogramBucketPresetsFromEnv() ([]MetricViewConfig, error) {
+	envPresets := os.Getenv(bucketPresetsEnv)
+	if envPresets == "" {
+		return nil, nil
+	}
+
+	var views []MetricViewConfig
+
+	for _, envPreset := range strings.Split(envPresets, ",") {
+		instrument, preset, ok := strings.Cut(strings.TrimSpace(envPreset), "=")
+		if !ok {
+			return nil, fmt.Errorf("parse %s: %w: %q", bucketPresetsEnv, ErrInvalidHistogramBucketPreset, envPreset)
+		}
+
+		views = append(views, MetricViewConfig{
+			Instrument:   strings.TrimSpace(instrument),
+			Kind:         HistogramInstrumentKind,
+			BucketPreset: HistogramBucketPreset(strings.TrimSpace(preset)),
+		})
+	}
+
+	return views, nil
+}
+
+// getExponentialHistogramAggregationFromEnv returns exponential histogram aggregation from max size and max scale env
+// for otlp exporter, nil when the default histogram aggregation is not base2_exponential_bucket_histogram
+// or max size and max scale is not set. max scale 0 is valid and unset max scale is 20
+func getExponentialHistogramAggregationFromEnv() (sdkmetric.Aggregation, error) {
+	if os.Getenv(histogramAggregationEnv) != string(ExponentialHistogramMetricAggregation) {
+		return nil, nil
+	}
+
+	var (
+		maxSize  int
+		maxScale = exponentialHistogramMaxScaleDefault
+	)
+
+	envMaxSize := os.Getenv(exponentialHistogramMaxSizeEnv)
+	envMaxScale := os.Getenv(exponentialHistogramMaxScaleEnv)
+
+	if envMaxSize == "" && envMaxScale == "" {
+		return nil, nil
+	}
+
+	if envMaxSize != "" {
+		var err error
+
+		maxSize, err = strconv.Atoi(envMaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", exponentialHistogramMaxSizeEnv, err)
+		}
+	}
+
+	if envMaxScale != "" {
+		var err error
+
+		maxScale, err = strconv.Atoi(envMaxScale)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", exponentialHistogramMaxScaleEnv, err)
+		}
+	}
+
+	return NewExponentialHistogramAggregation(int32(maxSize), int32(maxScale))
+}
+
+// getPrometheusServerFromEnv returns prometheus metrics server address and path
+func getPrometheusServerFromEnv() (string, string) {
+	host := getEnvOrDefault(prometheusHostEnv, prometheusHostEnvDefault)
+	port := getEnvOrDefault(prometheusPortEnv, prometheusPortEnvDefault)
+	path := getEnvOrDefault(prometheusPathEnv, prometheusPathEnvDefault)
+
+	return net.JoinHostPort(host, port), path
+}
+
+// getPrometheusOptsFromEnv returns prometheus exporter option from env
+func getPrometheusOptsFromEnv() ([]prometheus.Option, error) {
+	var opts []prometheus.Option
+
+	if namespace := os.Getenv(prometheusNamespaceEnv); namespace != "" {
+		opts = append(opts, prometheus.WithNamespace(namespace))
+	}
+
+	boolOpts := []struct {
+		env string
+		opt prometheus.Option
+	}{
+		{env: prometheusWithoutUnitsEnv, opt: prometheus.WithoutUnits()},
+		{env: prometheusWithoutCounterSuffixesEnv, opt: prometheus.WithoutCounterSuffixes()},
+		{env: prometheusWithoutScopeInfoEnv, opt: prometheus.WithoutScopeInfo()},
+		{env: prometheusWithoutTargetInfoEnv, opt: prometheus.WithoutTargetInfo()},
+	}
+
+	for _, boolOpt := range boolOpts {
+		enabled, err := getEnvBool(boolOpt.env)
+		if err != nil {
+			return nil, err
+		}
+
+		if enabled {
+			opts = append(opts, boolOpt.opt)
+		}
+	}
+
+	return opts, nil
+}
+
+// getStatsdOptFromEnv returns statsd exporter option from env, the option from argument override the env
+func getStatsdOptFromEnv(opt StatsdExporterOption) StatsdExporterOption {
+	if opt.Endpoint == "" {
+		opt.Endpoint = os.Getenv(statsdEndpointEnv)
+	}
+
+	if opt.Prefix == "" {
+		opt.Prefix = os.Getenv(statsdPrefixEnv)
+	}
+
+	return opt
+}
+
+// getRemoteWriteOptFromEnv returns remote write exporter option from env, the option from argument override the env
+func getRemoteWriteOptFromEnv(opt RemoteWriteExporterOption) RemoteWriteExporterOption {
+	if opt.Endpoint == "" {
+		opt.Endpoint = os.Getenv(remoteWriteEndpointEnv)
+	}
+
+	// auth from env is only used when no auth is set in argument
+	if opt.Username == "" && opt.Password == "" && opt.BearerToken == "" {
+		opt.Username = os.Getenv(remoteWriteUsernameEnv)
+		opt.Password = os.Getenv(remoteWritePasswordEnv)
+		opt.BearerToken = os.Getenv(remoteWriteBearerTokenEnv)
+	}
+
+	envHeaders := os.Getenv(remoteWriteHeadersEnv)
+	if envHeaders == "" {
+		return opt
+	}
+
+	headers := make(map[string]string)
+
+	for _, header := range strings.Split(envHeaders, ",") {
+		key, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	for key, value := range opt.Headers {
+		headers[key] = value
+	}
+
+	opt.Headers = headers
+
+	return opt
+}
+
+// getSeriesLimitFromEnv returns default metric stream series limit (default: 2000),
+// 0 is returned as SeriesLimitDisabled
+func getSeriesLimitFromEnv() (int, error) {
+	envLimit := os.Getenv(seriesLimitEnv)
+	if envLimit == "" {
+		return seriesLimitEnvDefault, nil
+	}
+
+	limit, err := strconv.Atoi(envLimit)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", seriesLimitEnv, err)
+	}
+
+	if limit <= 0 {
+		return SeriesLimitDisabled, nil
+	}
+
+	return limit, nil
+}
+
+// getRuntimeMetricsEnableFromEnv returns whether go runtime metrics is enabled (default: true),
+// the default is false for manual metric exporter so the collected snapshot is deterministic
+func getRuntimeMetricsEnableFromEnv() (bool, error) {
+	defaultValue := runtimeMetricsEnvDefault
+	if getMetricExporterTypeFromEnv() == ManualMetricExporter {
+		defaultValue = "false"
+	}
+
+	enabled, err := strconv.ParseBool(getEnvOrDefault(runtimeMetricsEnv, defaultValue))
+	if err != nil {
+		return false, fmt.Errorf("parse %s: %w", runtimeMetricsEnv, err)
+	}
+
+	return enabled, nil
+}
+
+// getHostMetricsOptionFromEnv returns host metrics option, nil when OTEL_HOST_METRICS is not enabled
+func getHostMetricsOptionFromEnv() (*HostMetricsOption, error) {
+	enabled, err := getEnvBool(hostMetricsEnv)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	opt := HostMetricsOption{ProcRoot: os.Getenv(hostMetricsProcRootEnv)}
+
+	if envInterval := os.Getenv(hostMetricsIntervalEnv); envInterval != "" {
+		interval, err := strconv.Atoi(envInterval)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", hostMetricsIntervalEnv, err)
+		}
+
+		opt.Interval = time.Duration(interval) * time.Millisecond
+	}
+
+	return &opt, nil
+}
+
+// getLogSeverityFilterFromEnv returns log severity filter option from env,
+// the minimum severity from argument override the env and the logger from argument is matched first
+func getLogSeverityFilterFromEnv(opt LogSeverityFilterOption) (LogSeverityFilterOption, error) {
+	if opt.MinSeverity == "" {
+		opt.MinSeverity = LogSeverity(strings.ToLower(os.Getenv(logMinSeverityEnv)))
+	}
+
+	envLoggers := os.Getenv(logMinSeverityLoggersEnv)
+	if envLoggers == "" {
+		return opt, nil
+	}
+
+	// copy so the env logger is not appended to the caller slice
+	opt.Loggers = append([]LoggerSeverity(nil), opt.Loggers...)
+
+	for _, envLogger := range strings.Split(envLoggers, ",") {
+		logger, severity, ok := strings.Cut(strings.TrimSpace(envLogger), "=")
+		if !ok {
+			return opt, fmt.Errorf("parse %s: %w: %q", logMinSeverityLoggersEnv, ErrInvalidLogSeverity, envLogger)
+		}
+
+		opt.Loggers = append(opt.Loggers, LoggerSeverity{
+			Logger:      strings.TrimSpace(logger),
+			MinSeverity: LogSeverity(strings.ToLower(strings.TrimSpace(severity))),
+		})
+	}
+
+	return opt, nil
+}
+
+// getSyslogOptFromEnv returns syslog exporter option from env, the option from argument override the env
+func getSyslogOptFromEnv(opt SyslogExporterOption) SyslogExporterOption {
+	if opt.Endpoint == "" {
+		opt.Endpoint = os.Getenv(syslogEndpointEnv)
+	}
+
+	if opt.Facility == "" {
+		opt.Facility = SyslogFacility(strings.ToLower(os.Getenv(syslogFacilityEnv)))
+	}
+
+	if opt.AppName == "" {
+		opt.AppName = os.Getenv(syslogAppNameEnv)
+	}
+
+	return opt
+}
+
+// getJournaldOptFromEnv returns journald exporter option from env, the option from argument override the env
+func getJournaldOptFromEnv(opt JournaldExporterOption) JournaldExporterOption {
+	if opt.Endpoint == "" {
+		opt.Endpoint = os.Getenv(journaldEndpointEnv)
+	}
+
+	if opt.Identifier == "" {
+		opt.Identifier = os.Getenv(journaldIdentifierEnv)
+	}
+
+	return opt
+}
+
+// getLogDedupOptFromEnv returns log dedup option from env, the option from argument override the env
+func getLogDedupOptFromEnv(opt LogDedupOption) (LogDedupOption, error) {
+	var err error
+
+	if opt.Window == 0 {
+		opt.Window, err = getEnvMillisecond(logDedupWindowEnv, 0)
+		if err != nil {
+			return opt, err
+		}
+	}
+
+	if opt.Limit == 0 {
+		opt.Limit, err = getEnvInt(logDedupLimitEnv, 0)
+		if err != nil {
+			return opt, err
+		}
+	}
+
+	if !opt.SampledTracesOnly {
+		opt.SampledTracesOnly, err = getEnvBool(logSampledTracesOnlyEnv)
+		if err != nil {
+			return opt, err
+		}
+	}
+
+	return opt, nil
+}
+
+// validateLogProcessorEnv returns error when the batch option or OTEL_BLRP_* env that is not overridden by the option
+// is invalid. the env is resolved by sdklog.NewBatchProcessor, it is read here only to report invalid value
+func validateLogProcessorEnv(opt LogProcessorOption) error {
+	var err error
+
+	if opt.ScheduleDelay == 0 {
+		opt.ScheduleDelay, err = getEnvMillisecond(logScheduleDelayEnv, logScheduleDelayEnvDefault)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLogProcessor, err)
+		}
+	}
+
+	if opt.ExportTimeout == 0 {
+		opt.ExportTimeout, err = getEnvMillisecond(logExportTimeoutEnv, logExportTimeoutEnvDefault)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLogProcessor, err)
+		}
+	}
+
+	if opt.MaxQueueSize == 0 {
+		opt.MaxQueueSize, err = getEnvInt(logMaxQueueSizeEnv, logMaxQueueSizeEnvDefault)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLogProcessor, err)
+		}
+	}
+
+	if opt.MaxExportBatchSize == 0 {
+		opt.MaxExportBatchSize, err = getEnvInt(logMaxExportBatchSizeEnv, logMaxBatchSizeEnvDefault)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLogProcessor, err)
+		}
+	}
+
+	return opt.validate()
+}
+
 func getLogExporterTypeFromEnv() LogExporterType {
 	var (
 		envExporterType    = os.Getenv(exporterTypeEnv)
@@ -100,3 +583,56 @@ func getProvidersEnable() (ProvidersEnable, error) {
 
 	return providers, nil
 }
+
+func getEnvOrDefault(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getEnvBool(key string) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("parse %s: %w", key, err)
+	}
+
+	return enabled, nil
+}
+
+// getEnvInt returns env as int, default value is returned when the env is not set
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+
+	return i, nil
+}
+
+// getEnvMillisecond returns env in millisecond as duration, default value is returned when the env is not set
+func getEnvMillisecond(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	ms, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,403 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// default debug page setting
+const (
+	debugErrorSpansLimitDefault = 100
+	debugMaxSpanNamesDefault    = 1000
+	debugSpanNameOther          = "_other"
+	debugConfigMask             = "******"
+	debugConfigDisabled         = "disabled"
+	debugConfigFromEnv          = "from env"
+)
+
+// debugConfigMaskSuffixes suffix of environment that hold credential, the value is masked
+var debugConfigMaskSuffixes = []string{"_HEADERS", "_PASSWORD", "_TOKEN", "_SECRET", "_KEY"}
+
+// debugLatencyBuckets upper bound of latency bucket, the last bucket is unbounded
+var debugLatencyBuckets = []time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	100 * time.Second,
+}
+
+var _ sdktrace.SpanProcessor = (*DebugSpanProcessor)(nil)
+
+// DebugPageOption option for debug page
+type DebugPageOption struct {
+	// Address serve the debug page on the address (e.g. ":55679"),
+	// empty will not start the server, mount Providers.DebugHandler on your own server
+	Address string
+	// ErrorSpansLimit number of the last error spans kept (default: 100)
+	ErrorSpansLimit int
+	// MaxSpanNames maximum distinct span name in latency stats,
+	// span name over the limit is counted as "_other" (default: 1000)
+	MaxSpanNames int
+}
+
+// DebugSpanProcessor span processor that keep running spans, latency per span name
+// and the last error spans in memory for the debug page
+type DebugSpanProcessor struct {
+	mu sync.Mutex
+
+	running     map[trace.SpanID]sdktrace.ReadOnlySpan
+	stats       map[string]*debugSpanStats
+	errorSpans  []sdktrace.ReadOnlySpan
+	errorsLimit int
+	maxNames    int
+}
+
+type debugSpanStats struct {
+	Name    string
+	Running int
+	Count   int
+	Errors  int
+	Buckets []int
+}
+
+// NewDebugSpanProcessor create debug span processor, keep the last opt.ErrorSpansLimit error spans
+// and latency stats of at most opt.MaxSpanNames span name, Address is not used
+func NewDebugSpanProcessor(opt DebugPageOption) *DebugSpanProcessor {
+	if opt.ErrorSpansLimit < 1 {
+		opt.ErrorSpansLimit = debugErrorSpansLimitDefault
+	}
+
+	if opt.MaxSpanNames < 1 {
+		opt.MaxSpanNames = debugMaxSpanNamesDefault
+	}
+
+	return &DebugSpanProcessor{
+		running:     make(map[trace.SpanID]sdktrace.ReadOnlySpan),
+		stats:       make(map[string]*debugSpanStats),
+		errorsLimit: opt.ErrorSpansLimit,
+		maxNames:    opt.MaxSpanNames,
+	}
+}
+
+// OnStart track the span as running span
+func (p *DebugSpanProcessor) OnStart(_ context.Context, span sdktrace.ReadWriteSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.running[span.SpanContext().SpanID()] = span
+	p.getStats(span.Name()).Running++
+}
+
+// OnEnd remove the span from running span and record the latency and error
+func (p *DebugSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.getStats(span.Name())
+
+	if _, ok := p.running[span.SpanContext().SpanID()]; ok {
+		delete(p.running, span.SpanContext().SpanID())
+		stats.Running--
+	}
+
+	stats.Count++
+	stats.Buckets[debugLatencyBucket(span.EndTime().Sub(span.StartTime()))]++
+
+	if span.Status().Code == codes.Error {
+		stats.Errors++
+
+		if len(p.errorSpans) >= p.errorsLimit {
+			p.errorSpans = p.errorSpans[1:]
+		}
+
+		p.errorSpans = append(p.errorSpans, span)
+	}
+}
+
+// Shutdown do nothing, the spans kept in memory
+func (p *DebugSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush do nothing, the spans kept in memory
+func (p *DebugSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// getStats stats of the span name, new span name over the limit use "_other" stats.
+// the stats is never removed so a span use the same stats on start and end
+func (p *DebugSpanProcessor) getStats(name string) *debugSpanStats {
+	stats, ok := p.stats[name]
+	if !ok && len(p.stats) >= p.maxNames {
+		name = debugSpanNameOther
+		stats, ok = p.stats[name]
+	}
+
+	if !ok {
+		stats = &debugSpanStats{Name: name, Buckets: make([]int, len(debugLatencyBuckets)+1)}
+		p.stats[name] = stats
+	}
+
+	return stats
+}
+
+func debugLatencyBucket(latency time.Duration) int {
+	for i, bound := range debugLatencyBuckets {
+		if latency < bound {
+			return i
+		}
+	}
+
+	return len(debugLatencyBuckets)
+}
+
+// DebugHandler http handler that show the debug page
+// running spans, latency per span name, the last error spans and effective provider configuration
+type DebugHandler struct {
+	processor *DebugSpanProcessor
+	config    map[string]string
+}
+
+// NewDebugHandler create debug page handler from the processor, config is effective configuration shown on the page
+func NewDebugHandler(processor *DebugSpanProcessor, config map[string]string) *DebugHandler {
+	return &DebugHandler{
+		processor: processor,
+		config:    config,
+	}
+}
+
+type debugSpan struct {
+	Name       string
+	TraceID    string
+	SpanID     string
+	StartTime  time.Time
+	Duration   time.Duration
+	Status     string
+	Attributes []string
+}
+
+type debugPageData struct {
+	Now          time.Time
+	Buckets      []string
+	Stats        []debugSpanStats
+	RunningSpans []debugSpan
+	ErrorSpans   []debugSpan
+	Config       [][2]string
+}
+
+// ServeHTTP render the debug page
+func (h *DebugHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	data := h.pageData()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err := debugPageTemplate.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *DebugHandler) pageData() debugPageData {
+	data := debugPageData{Now: time.Now()}
+
+	for _, bound := range debugLatencyBuckets {
+		data.Buckets = append(data.Buckets, "<"+bound.String())
+	}
+
+	data.Buckets = append(data.Buckets, ">="+debugLatencyBuckets[len(debugLatencyBuckets)-1].String())
+
+	for key, value := range h.config {
+		data.Config = append(data.Config, [2]string{key, value})
+	}
+
+	sort.Slice(data.Config, func(i, j int) bool { return data.Config[i][0] < data.Config[j][0] })
+
+	if h.processor == nil {
+		return data
+	}
+
+	h.processor.mu.Lock()
+	defer h.processor.mu.Unlock()
+
+	for _, stats := range h.processor.stats {
+		stats := *stats
+		stats.Buckets = append([]int(nil), stats.Buckets...)
+		data.Stats = append(data.Stats, stats)
+	}
+
+	sort.Slice(data.Stats, func(i, j int) bool { return data.Stats[i].Name < data.Stats[j].Name })
+
+	for _, span := range h.processor.running {
+		data.RunningSpans = append(data.RunningSpans, newDebugSpan(span, data.Now.Sub(span.StartTime())))
+	}
+
+	sort.Slice(data.RunningSpans, func(i, j int) bool {
+		return data.RunningSpans[i].StartTime.Before(data.RunningSpans[j].StartTime)
+	})
+
+	// newest error span first
+	for i := len(h.processor.errorSpans) - 1; i >= 0; i-- {
+		span := h.processor.errorSpans[i]
+		data.ErrorSpans = append(data.ErrorSpans, newDebugSpan(span, span.EndTime().Sub(span.StartTime())))
+	}
+
+	return data
+}
+
+func newDebugSpan(span sdktrace.ReadOnlySpan, duration time.Duration) debugSpan {
+	attrs := make([]string, 0, len(span.Attributes()))
+	for _, attr := range span.Attributes() {
+		attrs = append(attrs, string(attr.Key)+"="+attr.Value.Emit())
+	}
+
+	status := span.Status().Code.String()
+	if span.Status().Description != "" {
+		status += ": " + span.Status().Description
+	}
+
+	return debugSpan{
+		Name:       span.Name(),
+		TraceID:    span.SpanContext().TraceID().String(),
+		SpanID:     span.SpanContext().SpanID().String(),
+		StartTime:  span.StartTime(),
+		Duration:   duration,
+		Status:     status,
+		Attributes: attrs,
+	}
+}
+
+// newDebugConfig effective provider configuration from enabled providers, applied NewProviders option,
+// resource and OTEL_ environment. value of headers, password, token, secret and key environment is masked
+// and exporter option that can hold credential is not shown
+func newDebugConfig(providersEnable ProvidersEnable, res *resource.Resource, option *providersOption) map[string]string {
+	config := map[string]string{
+		"providers.trace":  strconv.FormatBool(providersEnable.Trace),
+		"providers.metric": strconv.FormatBool(providersEnable.Metric),
+		"providers.log":    strconv.FormatBool(providersEnable.Log),
+	}
+
+	if option != nil {
+		for key, value := range option.debugConfig() {
+			config["option."+key] = value
+		}
+	}
+
+	if res != nil {
+		for _, attr := range res.Attributes() {
+			config["resource."+string(attr.Key)] = attr.Value.Emit()
+		}
+	}
+
+	for _, env := range os.Environ() {
+		key, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(key, "OTEL_") {
+			continue
+		}
+
+		for _, suffix := range debugConfigMaskSuffixes {
+			if strings.HasSuffix(key, suffix) {
+				value = debugConfigMask
+				break
+			}
+		}
+
+		config["env."+key] = value
+	}
+
+	return config
+}
+
+// debugConfig applied option shown on the debug page, option that is not set is shown as resolved from env or disabled
+func (o *providersOption) debugConfig() map[string]string {
+	config := map[string]string{
+		"metric.runtime":            strconv.FormatBool(o.runtimeMetrics),
+		"metric.series_limit":       strconv.Itoa(o.metricExporterOpt.SeriesLimit.Default),
+		"metric.series_limit.views": strconv.Itoa(len(o.metricExporterOpt.SeriesLimit.Views)),
+		"metric.exemplar_filter":    debugOptionValue(o.exemplarFilter, debugConfigFromEnv),
+		"metric.host":               debugOptionValue(o.hostMetrics, debugConfigFromEnv),
+		"prometheus.server":         debugConfigDisabled,
+		"log.severity_filter":       debugOptionValue(o.logExporterOpt.SeverityFilter, debugConfigFromEnv),
+		"log.processor":             debugOptionValue(o.logExporterOpt.Processor, debugConfigFromEnv),
+		"log.dedup":                 debugOptionValue(o.logExporterOpt.Dedup, debugConfigFromEnv),
+		"log.std_redirect":          strconv.FormatBool(o.stdLog != nil),
+		"log.span_events":           debugOptionValue(o.logSpanEvents, debugConfigDisabled),
+		"trace.span_event_logs":     strconv.FormatBool(o.spanEventLogs != nil),
+		"debug_page":                debugOptionValue(o.debugPage, debugConfigDisabled),
+	}
+
+	if o.prometheusServer {
+		config["prometheus.server"] = o.prometheusServerAddress + o.prometheusServerPath
+	}
+
+	return config
+}
+
+// debugOptionValue format the option value, zero value or nil pointer is shown as unset
+func debugOptionValue(opt any, unset string) string {
+	value := reflect.ValueOf(opt)
+	if value.IsZero() {
+		return unset
+	}
+
+	return fmt.Sprintf("%+v", reflect.Indirect(value).Interface())
+}
+
+var debugPageTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>OpenTelemetry Debug</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>OpenTelemetry Debug</h1>
+<p>Generated at {{.Now.Format "2006-01-02T15:04:05.000Z07:00"}}</p>
+
+<h2>Span Latency</h2>
+<table>
+<tr><th>Name</th><th>Running</th><th>Count</th><th>Errors</th>{{range .Buckets}}<th>{{.}}</th>{{end}}</tr>
+{{range .Stats}}<tr><td>{{.Name}}</td><td>{{.Running}}</td><td>{{.Count}}</td><td>{{.Errors}}</td>{{range .Buckets}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+
+<h2>Running Spans</h2>
+<table>
+<tr><th>Name</th><th>Trace ID</th><th>Span ID</th><th>Start</th><th>Duration</th><th>Attributes</th></tr>
+{{range .RunningSpans}}<tr><td>{{.Name}}</td><td>{{.TraceID}}</td><td>{{.SpanID}}</td><td>{{.StartTime.Format "15:04:05.000"}}</td><td>{{.Duration}}</td><td>{{range .Attributes}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Error Spans</h2>
+<table>
+<tr><th>Name</th><th>Trace ID</th><th>Span ID</th><th>Start</th><th>Duration</th><th>Status</th><th>Attributes</th></tr>
+{{range .ErrorSpans}}<tr><td>{{.Name}}</td><td>{{.TraceID}}</td><td>{{.SpanID}}</td><td>{{.StartTime.Format "15:04:05.000"}}</td><td>{{.Duration}}</td><td>{{.Status}}</td><td>{{range .Attributes}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Configuration</h2>
+<table>
+<tr><th>Key</th><th>Value</th></tr>
+{{range .Config}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
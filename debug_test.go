@@ -0,0 +1,180 @@
+package otel
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newTestDebugTracer(opt DebugPageOption) (*sdktrace.TracerProvider, *DebugSpanProcessor) {
+	processor := NewDebugSpanProcessor(opt)
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor)), processor
+}
+
+func TestDebugSpanProcessorMaxSpanNames(t *testing.T) {
+	tp, processor := newTestDebugTracer(DebugPageOption{MaxSpanNames: 2})
+	tracer := tp.Tracer("test")
+
+	_, first := tracer.Start(context.Background(), "first")
+	first.End()
+
+	_, second := tracer.Start(context.Background(), "second")
+	second.End()
+
+	// span name over the limit is counted as _other on start and end
+	_, third := tracer.Start(context.Background(), "third")
+	_, fourth := tracer.Start(context.Background(), "fourth")
+
+	if got := processor.stats[debugSpanNameOther].Running; got != 2 {
+		t.Errorf("_other running = %d, want 2", got)
+	}
+
+	third.End()
+	fourth.End()
+
+	_, again := tracer.Start(context.Background(), "first")
+	again.End()
+
+	data := NewDebugHandler(processor, nil).pageData()
+
+	got := make(map[string][2]int)
+	for _, stats := range data.Stats {
+		got[stats.Name] = [2]int{stats.Running, stats.Count}
+	}
+
+	want := map[string][2]int{
+		"first":            {0, 2},
+		"second":           {0, 1},
+		debugSpanNameOther: {0, 2},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("stats = %v, want %v", got, want)
+	}
+
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("stats[%s] running, count = %v, want %v", name, got[name], value)
+		}
+	}
+}
+
+func TestDebugSpanProcessorErrorSpansLimit(t *testing.T) {
+	tp, processor := newTestDebugTracer(DebugPageOption{ErrorSpansLimit: 2})
+	tracer := tp.Tracer("test")
+
+	for _, name := range []string{"first", "second", "third"} {
+		_, span := tracer.Start(context.Background(), name)
+		span.SetStatus(codes.Error, "failed")
+		span.End()
+	}
+
+	_, ok := tracer.Start(context.Background(), "ok")
+	ok.End()
+
+	data := NewDebugHandler(processor, nil).pageData()
+
+	if len(data.ErrorSpans) != 2 {
+		t.Fatalf("error spans = %d, want 2", len(data.ErrorSpans))
+	}
+
+	// newest error span first
+	if data.ErrorSpans[0].Name != "third" || data.ErrorSpans[1].Name != "second" {
+		t.Errorf("error spans = %s, %s, want third, second", data.ErrorSpans[0].Name, data.ErrorSpans[1].Name)
+	}
+
+	if data.ErrorSpans[0].Status != "Error: failed" {
+		t.Errorf("status = %q, want %q", data.ErrorSpans[0].Status, "Error: failed")
+	}
+}
+
+func TestNewDebugSpanProcessorDefault(t *testing.T) {
+	processor := NewDebugSpanProcessor(DebugPageOption{})
+
+	if processor.errorsLimit != debugErrorSpansLimitDefault || processor.maxNames != debugMaxSpanNamesDefault {
+		t.Errorf("limit = %d, %d, want %d, %d",
+			processor.errorsLimit, processor.maxNames, debugErrorSpansLimitDefault, debugMaxSpanNamesDefault)
+	}
+}
+
+func TestNewDebugConfigMask(t *testing.T) {
+	env := map[string]string{
+		"OTEL_EXPORTER_OTLP_HEADERS":                         "authorization=secret",
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_PASSWORD":     "password",
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_BEARER_TOKEN": "token",
+		"OTEL_EXPORTER_CLIENT_SECRET":                        "secret",
+		"OTEL_EXPORTER_OTLP_CLIENT_KEY":                      "/etc/otel/client.key",
+		"OTEL_SERVICE_NAME":                                  "payments",
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_USERNAME":     "user",
+	}
+
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	config := newDebugConfig(ProvidersEnable{Trace: true}, nil, nil)
+
+	want := map[string]string{
+		"OTEL_EXPORTER_OTLP_HEADERS":                         debugConfigMask,
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_PASSWORD":     debugConfigMask,
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_BEARER_TOKEN": debugConfigMask,
+		"OTEL_EXPORTER_CLIENT_SECRET":                        debugConfigMask,
+		"OTEL_EXPORTER_OTLP_CLIENT_KEY":                      debugConfigMask,
+		"OTEL_SERVICE_NAME":                                  "payments",
+		"OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_USERNAME":     "user",
+	}
+
+	for key, value := range want {
+		if got := config["env."+key]; got != value {
+			t.Errorf("env.%s = %q, want %q", key, got, value)
+		}
+	}
+
+	if config["providers.trace"] != "true" || config["providers.metric"] != "false" {
+		t.Errorf("unexpected providers config %v", config)
+	}
+}
+
+func TestNewProvidersDebugConfig(t *testing.T) {
+	setTestTracerProvider(t)
+	t.Setenv(providersEnv, "trace,metric")
+	t.Setenv(exporterTypeEnv, "")
+	t.Setenv(traceExporterTypeEnv, string(StdOutTraceExporter))
+	t.Setenv(metricExporterTypeEnv, string(ManualMetricExporter))
+	t.Setenv(runtimeMetricsEnv, "false")
+	t.Setenv(seriesLimitEnv, "")
+
+	providers, err := NewProviders(context.Background(),
+		WithDebugPage(DebugPageOption{MaxSpanNames: 10}),
+		WithLogProcessor(LogProcessorOption{Simple: true}),
+		WithExemplarFilter(AlwaysOnExemplarFilter),
+		WithPrometheusServer("", ""),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = providers.Shutdown(context.Background()) })
+
+	want := map[string]string{
+		"option.debug_page":             "{Address: ErrorSpansLimit:0 MaxSpanNames:10}",
+		"option.log.processor":          "{Simple:true ScheduleDelay:0s ExportTimeout:0s MaxQueueSize:0 MaxExportBatchSize:0}",
+		"option.log.dedup":              debugConfigFromEnv,
+		"option.metric.exemplar_filter": string(AlwaysOnExemplarFilter),
+		"option.metric.runtime":         "false",
+		"option.metric.host":            debugConfigFromEnv,
+		// default series limit is resolved from env by the metric provider
+		"option.metric.series_limit": strconv.Itoa(seriesLimitEnvDefault),
+		"option.prometheus.server":   debugConfigDisabled,
+		"option.log.std_redirect":    "false",
+	}
+
+	for key, value := range want {
+		if got := providers.DebugHandler.config[key]; got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
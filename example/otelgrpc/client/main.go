@@ -84,7 +84,7 @@ func callSayHello(c api.HelloServiceClient) error {
 	span.SetAttributes(attribute.String("greeting", "World"))
 	response, err := c.SayHello(ctx, &api.HelloRequest{Greeting: "World"})
 	if err != nil {
-		span.RecordError(err)
+		otelprovider.RecordError(span, err)
 		return fmt.Errorf("calling SayHello: %w", err)
 	}
 	log.Printf("Response from server: %s", response.Reply)
@@ -0,0 +1,108 @@
+package otel
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// default host metrics setting
+const (
+	hostMetricsIntervalDefault = 10 * time.Second
+	hostMetricsProcRootDefault = "/proc"
+)
+
+// HostMetricsOption option for linux host and process metrics
+type HostMetricsOption struct {
+	// Interval minimum interval between /proc read, collection in between reuse the last read (default: 10s)
+	Interval time.Duration
+	// ProcRoot proc filesystem root, e.g. "/host/proc" in container or fixture directory in test (default: /proc)
+	ProcRoot string
+	// PID process id of the process metrics (default: current process)
+	PID int
+}
+
+// HostMetrics collect linux host and process cpu, memory, disk io, network and file descriptor metrics from /proc
+// using semantic convention name. file that can't be read is skipped so the metrics work on partial /proc tree,
+// Start returns ErrHostMetricsUnsupported on other os
+type HostMetrics struct {
+	opt HostMetricsOption
+
+	mu           sync.Mutex
+	snapshot     hostSnapshot
+	lastRead     time.Time
+	registration metric.Registration
+}
+
+// hostSnapshot the last /proc read
+type hostSnapshot struct {
+	cpu []hostModeValue
+
+	memory      []hostModeValue
+	memoryLimit int64
+
+	disks    []hostDeviceStat
+	networks []hostDeviceStat
+
+	fileDescriptors     int64
+	fileDescriptorLimit int64
+
+	process hostProcessStat
+}
+
+type hostModeValue struct {
+	mode  string
+	value float64
+}
+
+// hostDeviceStat disk or network interface counter, operations is disk operation or network packet
+type hostDeviceStat struct {
+	device                string
+	readBytes, writeBytes int64
+	readOps, writeOps     int64
+}
+
+type hostProcessStat struct {
+	cpuUser, cpuSystem         float64
+	memoryUsage, memoryVirtual int64
+	threads                    int64
+	diskRead, diskWrite        int64
+	fileDescriptors            int64
+}
+
+// NewHostMetrics create linux host and process metrics, call Start to register it on the meter provider
+//
+//	hostMetrics := otel.NewHostMetrics(otel.HostMetricsOption{Interval: 30 * time.Second})
+//	err := hostMetrics.Start(metricProvider)
+func NewHostMetrics(opt HostMetricsOption) *HostMetrics {
+	if opt.Interval <= 0 {
+		opt.Interval = hostMetricsIntervalDefault
+	}
+
+	if opt.ProcRoot == "" {
+		opt.ProcRoot = hostMetricsProcRootDefault
+	}
+
+	if opt.PID <= 0 {
+		opt.PID = os.Getpid()
+	}
+
+	return &HostMetrics{opt: opt}
+}
+
+// Stop unregister the host and process metric instruments
+func (h *HostMetrics) Stop() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.registration == nil {
+		return nil
+	}
+
+	err := h.registration.Unregister()
+	h.registration = nil
+
+	return err
+}
@@ -0,0 +1,432 @@
+//go:build linux
+
+package otel
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// host metrics semantic convention attribute
+const (
+	hostCPUModeKey            = attribute.Key("cpu.mode")
+	hostMemoryStateKey        = attribute.Key("system.memory.state")
+	hostDeviceKey             = attribute.Key("system.device")
+	hostDiskIODirectionKey    = attribute.Key("disk.io.direction")
+	hostNetworkInterfaceKey   = attribute.Key("network.interface.name")
+	hostNetworkIODirectionKey = attribute.Key("network.io.direction")
+)
+
+// hostClockTicks USER_HZ of /proc cpu time, it is 100 on all supported linux architecture
+const hostClockTicks = 100
+
+// Start register the host and process metric instruments on the meter provider
+func (h *HostMetrics) Start(provider metric.MeterProvider) error {
+	meter := provider.Meter(instrumentationName + "/host")
+
+	var (
+		errs        []error
+		timeCounter = func(name, description string) metric.Float64ObservableCounter {
+			instrument, err := meter.Float64ObservableCounter(name, metric.WithDescription(description), metric.WithUnit("s"))
+			errs = append(errs, err)
+			return instrument
+		}
+		counter = func(name, description, unit string) metric.Int64ObservableCounter {
+			instrument, err := meter.Int64ObservableCounter(name, metric.WithDescription(description), metric.WithUnit(unit))
+			errs = append(errs, err)
+			return instrument
+		}
+		upDownCounter = func(name, description, unit string) metric.Int64ObservableUpDownCounter {
+			instrument, err := meter.Int64ObservableUpDownCounter(name, metric.WithDescription(description), metric.WithUnit(unit))
+			errs = append(errs, err)
+			return instrument
+		}
+	)
+
+	var (
+		systemCPUTime             = timeCounter("system.cpu.time", "Seconds each logical CPU spent on each mode.")
+		systemMemoryUsage         = upDownCounter("system.memory.usage", "Reports memory in use by state.", "By")
+		systemMemoryLimit         = upDownCounter("system.memory.limit", "Total memory available in the system.", "By")
+		systemDiskIO              = counter("system.disk.io", "Disk bytes transferred.", "By")
+		systemDiskOperations      = counter("system.disk.operations", "Disk operations count.", "{operation}")
+		systemNetworkIO           = counter("system.network.io", "Network bytes transferred.", "By")
+		systemNetworkPackets      = counter("system.network.packets", "Network packets transferred.", "{packet}")
+		systemFileDescriptors     = upDownCounter("system.file_descriptor.count", "Number of allocated file descriptors in the system.", "{file_descriptor}")
+		systemFileDescriptorLimit = upDownCounter("system.file_descriptor.limit", "Maximum number of file descriptors in the system.", "{file_descriptor}")
+		processCPUTime            = timeCounter("process.cpu.time", "Total CPU seconds broken down by different CPU modes.")
+		processMemoryUsage        = upDownCounter("process.memory.usage", "The amount of physical memory in use.", "By")
+		processMemoryVirtual      = upDownCounter("process.memory.virtual", "The amount of committed virtual memory.", "By")
+		processThreads            = upDownCounter("process.thread.count", "Process threads count.", "{thread}")
+		processDiskIO             = counter("process.disk.io", "Disk bytes transferred.", "By")
+		processFileDescriptors    = upDownCounter("process.open_file_descriptor.count", "Number of file descriptors in use by the process.", "{count}")
+	)
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	var (
+		readAttr  = metric.WithAttributes(hostDiskIODirectionKey.String("read"))
+		writeAttr = metric.WithAttributes(hostDiskIODirectionKey.String("write"))
+	)
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if time.Since(h.lastRead) >= h.opt.Interval {
+			h.snapshot = h.read()
+			h.lastRead = time.Now()
+		}
+
+		s := h.snapshot
+
+		for _, cpu := range s.cpu {
+			o.ObserveFloat64(systemCPUTime, cpu.value, metric.WithAttributes(hostCPUModeKey.String(cpu.mode)))
+		}
+
+		for _, memory := range s.memory {
+			o.ObserveInt64(systemMemoryUsage, int64(memory.value), metric.WithAttributes(hostMemoryStateKey.String(memory.mode)))
+		}
+
+		if s.memoryLimit > 0 {
+			o.ObserveInt64(systemMemoryLimit, s.memoryLimit)
+		}
+
+		for _, disk := range s.disks {
+			device := hostDeviceKey.String(disk.device)
+			o.ObserveInt64(systemDiskIO, disk.readBytes, metric.WithAttributes(device, hostDiskIODirectionKey.String("read")))
+			o.ObserveInt64(systemDiskIO, disk.writeBytes, metric.WithAttributes(device, hostDiskIODirectionKey.String("write")))
+			o.ObserveInt64(systemDiskOperations, disk.readOps, metric.WithAttributes(device, hostDiskIODirectionKey.String("read")))
+			o.ObserveInt64(systemDiskOperations, disk.writeOps, metric.WithAttributes(device, hostDiskIODirectionKey.String("write")))
+		}
+
+		for _, network := range s.networks {
+			device := hostNetworkInterfaceKey.String(network.device)
+			o.ObserveInt64(systemNetworkIO, network.readBytes, metric.WithAttributes(device, hostNetworkIODirectionKey.String("receive")))
+			o.ObserveInt64(systemNetworkIO, network.writeBytes, metric.WithAttributes(device, hostNetworkIODirectionKey.String("transmit")))
+			o.ObserveInt64(systemNetworkPackets, network.readOps, metric.WithAttributes(device, hostNetworkIODirectionKey.String("receive")))
+			o.ObserveInt64(systemNetworkPackets, network.writeOps, metric.WithAttributes(device, hostNetworkIODirectionKey.String("transmit")))
+		}
+
+		if s.fileDescriptorLimit > 0 {
+			o.ObserveInt64(systemFileDescriptors, s.fileDescriptors)
+			o.ObserveInt64(systemFileDescriptorLimit, s.fileDescriptorLimit)
+		}
+
+		p := s.process
+		o.ObserveFloat64(processCPUTime, p.cpuUser, metric.WithAttributes(hostCPUModeKey.String("user")))
+		o.ObserveFloat64(processCPUTime, p.cpuSystem, metric.WithAttributes(hostCPUModeKey.String("system")))
+		o.ObserveInt64(processMemoryUsage, p.memoryUsage)
+		o.ObserveInt64(processMemoryVirtual, p.memoryVirtual)
+		o.ObserveInt64(processThreads, p.threads)
+		o.ObserveInt64(processDiskIO, p.diskRead, readAttr)
+		o.ObserveInt64(processDiskIO, p.diskWrite, writeAttr)
+		o.ObserveInt64(processFileDescriptors, p.fileDescriptors)
+
+		return nil
+	}, systemCPUTime, systemMemoryUsage, systemMemoryLimit, systemDiskIO, systemDiskOperations,
+		systemNetworkIO, systemNetworkPackets, systemFileDescriptors, systemFileDescriptorLimit,
+		processCPUTime, processMemoryUsage, processMemoryVirtual, processThreads,
+		processDiskIO, processFileDescriptors)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.registration = registration
+
+	return nil
+}
+
+// read /proc, error is reported to otel error handler and the failed part is left empty
+func (h *HostMetrics) read() hostSnapshot {
+	var (
+		s          hostSnapshot
+		err        error
+		errs       []error
+		processDir = filepath.Join(h.opt.ProcRoot, strconv.Itoa(h.opt.PID))
+	)
+
+	s.cpu, err = readHostCPU(filepath.Join(h.opt.ProcRoot, "stat"))
+	errs = append(errs, err)
+
+	s.memory, s.memoryLimit, err = readHostMemory(filepath.Join(h.opt.ProcRoot, "meminfo"))
+	errs = append(errs, err)
+
+	s.disks, err = readHostDisks(filepath.Join(h.opt.ProcRoot, "diskstats"))
+	errs = append(errs, err)
+
+	s.networks, err = readHostNetworks(filepath.Join(h.opt.ProcRoot, "net", "dev"))
+	errs = append(errs, err)
+
+	s.fileDescriptors, s.fileDescriptorLimit, err = readHostFileDescriptors(filepath.Join(h.opt.ProcRoot, "sys", "fs", "file-nr"))
+	errs = append(errs, err)
+
+	s.process, err = readHostProcess(processDir)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		otel.Handle(fmt.Errorf("host metrics: %w", err))
+	}
+
+	return s
+}
+
+// readHostCPU read aggregated cpu time from /proc/stat
+func readHostCPU(path string) ([]hostModeValue, error) {
+	var cpu []hostModeValue
+
+	err := readProcLines(path, func(fields []string) bool {
+		if fields[0] != "cpu" || len(fields) < 9 {
+			return true
+		}
+
+		ticks := parseProcInts(fields[1:9])
+		cpu = []hostModeValue{
+			{mode: "user", value: float64(ticks[0]) / hostClockTicks},
+			{mode: "nice", value: float64(ticks[1]) / hostClockTicks},
+			{mode: "system", value: float64(ticks[2]) / hostClockTicks},
+			{mode: "idle", value: float64(ticks[3]) / hostClockTicks},
+			{mode: "iowait", value: float64(ticks[4]) / hostClockTicks},
+			{mode: "interrupt", value: float64(ticks[5]+ticks[6]) / hostClockTicks},
+			{mode: "steal", value: float64(ticks[7]) / hostClockTicks},
+		}
+
+		return false
+	})
+
+	return cpu, err
+}
+
+// readHostMemory read memory usage by state and total memory from /proc/meminfo
+func readHostMemory(path string) ([]hostModeValue, int64, error) {
+	info, err := readProcKeyValues(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		total   = info["MemTotal"] * 1024
+		free    = info["MemFree"] * 1024
+		buffers = info["Buffers"] * 1024
+		cached  = (info["Cached"] + info["SReclaimable"]) * 1024
+	)
+
+	return []hostModeValue{
+		{mode: "used", value: float64(total - free - buffers - cached)},
+		{mode: "free", value: float64(free)},
+		{mode: "buffers", value: float64(buffers)},
+		{mode: "cached", value: float64(cached)},
+	}, total, nil
+}
+
+// readHostDisks read disk io from /proc/diskstats, loop and ram device are skipped
+// and partition is skipped because the io is already counted on the disk
+func readHostDisks(path string) ([]hostDeviceStat, error) {
+	var (
+		disks   []hostDeviceStat
+		devices = make(map[string]bool)
+	)
+
+	err := readProcLines(path, func(fields []string) bool {
+		if len(fields) < 10 || strings.HasPrefix(fields[2], "loop") || strings.HasPrefix(fields[2], "ram") {
+			return true
+		}
+
+		// reads completed, reads merged, sectors read, time reading, writes completed, writes merged, sectors written
+		values := parseProcInts(fields[3:10])
+		disks = append(disks, hostDeviceStat{
+			device:     fields[2],
+			readOps:    values[0],
+			readBytes:  values[2] * 512,
+			writeOps:   values[4],
+			writeBytes: values[6] * 512,
+		})
+		devices[fields[2]] = true
+
+		return true
+	})
+
+	filtered := disks[:0]
+	for _, disk := range disks {
+		if !isHostDiskPartition(disk.device, devices) {
+			filtered = append(filtered, disk)
+		}
+	}
+
+	return filtered, err
+}
+
+// isHostDiskPartition returns true when the device is partition of other device,
+// e.g. sda1 of sda, nvme0n1p1 of nvme0n1 and mmcblk0p1 of mmcblk0
+func isHostDiskPartition(device string, devices map[string]bool) bool {
+	number := strings.TrimRightFunc(device, unicode.IsDigit)
+	if number == device {
+		return false
+	}
+
+	return devices[number] || (strings.HasSuffix(number, "p") && devices[strings.TrimSuffix(number, "p")])
+}
+
+// readHostNetworks read network interface io from /proc/net/dev
+func readHostNetworks(path string) ([]hostDeviceStat, error) {
+	var networks []hostDeviceStat
+
+	err := readProcLines(path, func(fields []string) bool {
+		if len(fields) < 11 || !strings.HasSuffix(fields[0], ":") {
+			return true
+		}
+
+		// receive bytes, packets, errs, drop, fifo, frame, compressed, multicast, transmit bytes, packets
+		values := parseProcInts(fields[1:11])
+		networks = append(networks, hostDeviceStat{
+			device:     strings.TrimSuffix(fields[0], ":"),
+			readBytes:  values[0],
+			readOps:    values[1],
+			writeBytes: values[8],
+			writeOps:   values[9],
+		})
+
+		return true
+	}, procLineSplitInterface)
+
+	return networks, err
+}
+
+// readHostFileDescriptors read allocated and maximum file descriptor from /proc/sys/fs/file-nr
+func readHostFileDescriptors(path string) (int64, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return 0, 0, fmt.Errorf("invalid %s", path)
+	}
+
+	values := parseProcInts(fields[:3])
+
+	// allocated, allocated but unused, maximum
+	return values[0] - values[1], values[2], nil
+}
+
+// readHostProcess read process cpu, memory, thread, io and file descriptor from /proc/<pid>
+func readHostProcess(dir string) (hostProcessStat, error) {
+	var (
+		p    hostProcessStat
+		errs []error
+	)
+
+	if data, err := os.ReadFile(filepath.Join(dir, "stat")); err != nil {
+		errs = append(errs, err)
+	} else if i := strings.LastIndexByte(string(data), ')'); i >= 0 {
+		// field after the command name start from the process state, utime and stime is the 12th and 13th
+		fields := strings.Fields(string(data)[i+1:])
+		if len(fields) > 12 {
+			values := parseProcInts(fields[11:13])
+			p.cpuUser = float64(values[0]) / hostClockTicks
+			p.cpuSystem = float64(values[1]) / hostClockTicks
+		}
+	}
+
+	if status, err := readProcKeyValues(filepath.Join(dir, "status")); err != nil {
+		errs = append(errs, err)
+	} else {
+		p.memoryUsage = status["VmRSS"] * 1024
+		p.memoryVirtual = status["VmSize"] * 1024
+		p.threads = status["Threads"]
+	}
+
+	// io need the same user or CAP_SYS_PTRACE, it is optional
+	if io, err := readProcKeyValues(filepath.Join(dir, "io")); err == nil {
+		p.diskRead = io["read_bytes"]
+		p.diskWrite = io["write_bytes"]
+	}
+
+	if fds, err := os.ReadDir(filepath.Join(dir, "fd")); err != nil {
+		errs = append(errs, err)
+	} else {
+		p.fileDescriptors = int64(len(fds))
+	}
+
+	return p, errors.Join(errs...)
+}
+
+// procLineSplitInterface separate "eth0:123" network interface name and the first value of /proc/net/dev line
+func procLineSplitInterface(line string) string {
+	return strings.Replace(line, ":", ": ", 1)
+}
+
+// readProcLines call fn with fields of each non empty line until fn returns false
+func readProcLines(path string, fn func(fields []string) bool, transforms ...func(line string) string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, transform := range transforms {
+			line = transform(line)
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if !fn(fields) {
+			break
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readProcKeyValues read "Key: value [kB]" file such as /proc/meminfo and /proc/<pid>/status
+func readProcKeyValues(path string) (map[string]int64, error) {
+	values := make(map[string]int64)
+
+	err := readProcLines(path, func(fields []string) bool {
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], ":") {
+			return true
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err == nil {
+			values[strings.TrimSuffix(fields[0], ":")] = value
+		}
+
+		return true
+	})
+
+	return values, err
+}
+
+// parseProcInts parse the fields as int64, invalid field is zero
+func parseProcInts(fields []string) []int64 {
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		values[i], _ = strconv.ParseInt(field, 10, 64)
+	}
+
+	return values
+}
@@ -0,0 +1,180 @@
+package otel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// writeTestProcRoot write /proc fixture of the file, pid 42 is the process
+func writeTestProcRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func collectTestHostMetrics(t *testing.T, opt HostMetricsOption) string {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	hostMetrics := NewHostMetrics(opt)
+	if err := hostMetrics.Start(provider); err != nil {
+		t.Fatal(err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := hostMetrics.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	return FormatMetricSnapshot(&rm)
+}
+
+func TestHostMetrics(t *testing.T) {
+	root := writeTestProcRoot(t, map[string]string{
+		"stat": "cpu  100 200 300 400 500 600 700 800 0 0\ncpu0 100 200 300 400 500 600 700 800 0 0\n",
+		"meminfo": "MemTotal:       1000 kB\nMemFree:         100 kB\nBuffers:          50 kB\n" +
+			"Cached:          200 kB\nSReclaimable:     50 kB\n",
+		"diskstats": "   8       0 sda 10 0 8 0 20 0 16 0 0 0 0\n" +
+			"   8       1 sda1 5 0 4 0 10 0 8 0 0 0 0\n" +
+			" 259       0 nvme0n1 30 0 24 0 40 0 32 0 0 0 0\n" +
+			" 259       1 nvme0n1p1 30 0 24 0 40 0 32 0 0 0 0\n" +
+			"   7       0 loop0 1 0 1 0 1 0 1 0 0 0 0\n",
+		"net/dev": "Inter-|   Receive                                                |  Transmit\n" +
+			" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n" +
+			"    lo:100 1 0 0 0 0 0 0 100 1 0 0 0 0 0 0\n" +
+			"  eth0:2000 20 0 0 0 0 0 0 3000 30 0 0 0 0 0 0\n",
+		"sys/fs/file-nr": "512\t12\t1024\n",
+		"42/stat":        "42 (my (app)) S 1 42 42 0 -1 4194560 100 0 0 0 250 150 0 0 20 0 8 0 100 0 0\n",
+		"42/status":      "Name:\tapp\nVmSize:\t    2000 kB\nVmRSS:\t     500 kB\nThreads:\t8\n",
+		"42/io":          "rchar: 1\nwchar: 2\nread_bytes: 4096\nwrite_bytes: 8192\n",
+		"42/fd/0":        "",
+		"42/fd/1":        "",
+		"42/net/dev":     "  eth0:9999 20 0 0 0 0 0 0 9999 30 0 0 0 0 0 0\n",
+	})
+
+	got := collectTestHostMetrics(t, HostMetricsOption{ProcRoot: root, PID: 42})
+
+	want := `scope github.com/erry-az/otel-go/host
+  metric process.cpu.time s sum float64 monotonic cumulative
+    {cpu.mode=system} 1.5
+    {cpu.mode=user} 2.5
+  metric process.disk.io By sum int64 monotonic cumulative
+    {disk.io.direction=read} 4096
+    {disk.io.direction=write} 8192
+  metric process.memory.usage By sum int64 cumulative
+    {} 512000
+  metric process.memory.virtual By sum int64 cumulative
+    {} 2048000
+  metric process.open_file_descriptor.count {count} sum int64 cumulative
+    {} 2
+  metric process.thread.count {thread} sum int64 cumulative
+    {} 8
+  metric system.cpu.time s sum float64 monotonic cumulative
+    {cpu.mode=idle} 4
+    {cpu.mode=interrupt} 13
+    {cpu.mode=iowait} 5
+    {cpu.mode=nice} 2
+    {cpu.mode=steal} 8
+    {cpu.mode=system} 3
+    {cpu.mode=user} 1
+  metric system.disk.io By sum int64 monotonic cumulative
+    {disk.io.direction=read,system.device=nvme0n1} 12288
+    {disk.io.direction=read,system.device=sda} 4096
+    {disk.io.direction=write,system.device=nvme0n1} 16384
+    {disk.io.direction=write,system.device=sda} 8192
+  metric system.disk.operations {operation} sum int64 monotonic cumulative
+    {disk.io.direction=read,system.device=nvme0n1} 30
+    {disk.io.direction=read,system.device=sda} 10
+    {disk.io.direction=write,system.device=nvme0n1} 40
+    {disk.io.direction=write,system.device=sda} 20
+  metric system.file_descriptor.count {file_descriptor} sum int64 cumulative
+    {} 500
+  metric system.file_descriptor.limit {file_descriptor} sum int64 cumulative
+    {} 1024
+  metric system.memory.limit By sum int64 cumulative
+    {} 1024000
+  metric system.memory.usage By sum int64 cumulative
+    {system.memory.state=buffers} 51200
+    {system.memory.state=cached} 256000
+    {system.memory.state=free} 102400
+    {system.memory.state=used} 614400
+  metric system.network.io By sum int64 monotonic cumulative
+    {network.interface.name=eth0,network.io.direction=receive} 2000
+    {network.interface.name=eth0,network.io.direction=transmit} 3000
+    {network.interface.name=lo,network.io.direction=receive} 100
+    {network.interface.name=lo,network.io.direction=transmit} 100
+  metric system.network.packets {packet} sum int64 monotonic cumulative
+    {network.interface.name=eth0,network.io.direction=receive} 20
+    {network.interface.name=eth0,network.io.direction=transmit} 30
+    {network.interface.name=lo,network.io.direction=receive} 1
+    {network.interface.name=lo,network.io.direction=transmit} 1
+`
+
+	if got != want {
+		t.Errorf("got host metrics:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHostMetricsPartialProcRoot(t *testing.T) {
+	root := writeTestProcRoot(t, map[string]string{
+		"meminfo": "MemTotal:       1000 kB\n",
+	})
+
+	got := collectTestHostMetrics(t, HostMetricsOption{ProcRoot: root, PID: 42})
+
+	if !strings.Contains(got, "metric system.memory.limit By sum int64 cumulative\n    {} 1024000\n") {
+		t.Errorf("readable file is not reported:\n%s", got)
+	}
+
+	if strings.Contains(got, "system.cpu.time") || strings.Contains(got, "system.file_descriptor") {
+		t.Errorf("missing file is reported:\n%s", got)
+	}
+}
+
+func TestIsHostDiskPartition(t *testing.T) {
+	devices := map[string]bool{"sda": true, "sda1": true, "nvme0n1": true, "nvme0n1p2": true, "mmcblk0": true, "mmcblk0p1": true, "md0": true, "dm-0": true}
+
+	tests := map[string]bool{
+		"sda":       false,
+		"sda1":      true,
+		"nvme0n1":   false,
+		"nvme0n1p2": true,
+		"mmcblk0":   false,
+		"mmcblk0p1": true,
+		"md0":       false,
+		"dm-0":      false,
+	}
+
+	for device, want := range tests {
+		if got := isHostDiskPartition(device, devices); got != want {
+			t.Errorf("isHostDiskPartition(%q) = %v, want %v", device, got, want)
+		}
+	}
+}
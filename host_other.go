@@ -0,0 +1,10 @@
+//go:build !linux
+
+package otel
+
+import "go.opentelemetry.io/otel/metric"
+
+// Start returns ErrHostMetricsUnsupported, host metrics is read from /proc and only run on linux
+func (h *HostMetrics) Start(metric.MeterProvider) error {
+	return ErrHostMetricsUnsupported
+}
@@ -0,0 +1,168 @@
+package otel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// metricInstrumentTag struct tag of the metric instrument field
+const metricInstrumentTag = "otel"
+
+var (
+	// metricInstrumentNameRegexp instrument name syntax from otel metric api spec
+	metricInstrumentNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_./-]{0,254}$`)
+	// metricInstrumentUnitRegexp instrument unit is printable ascii string up to 63 characters
+	metricInstrumentUnitRegexp = regexp.MustCompile(`^[\x20-\x7E]{0,63}$`)
+)
+
+// metricInstrumentOption parsed otel tag
+type metricInstrumentOption struct {
+	name        string
+	unit        string
+	description string
+}
+
+// NewMetricInstruments create every instrument field of the struct pointer from the otel tag,
+// the tag is the instrument name followed by optional unit and desc, desc must be the last option when it contain comma.
+// field without tag or with "-" tag is skipped, nil meter use the global meter provider.
+// every invalid field is reported in single joined error
+//
+//	type Metrics struct {
+//		Requests metric.Int64Counter     `otel:"http.server.requests,unit={request},desc=Number of requests"`
+//		Duration metric.Float64Histogram `otel:"http.server.duration,unit=s,desc=Duration of requests"`
+//	}
+//
+//	var m Metrics
+//	err := otel.NewMetricInstruments(providers.MetricProvider.Meter("my-service"), &m)
+func NewMetricInstruments(meter metric.Meter, instruments any) error {
+	if meter == nil {
+		meter = otel.Meter(instrumentationName)
+	}
+
+	value := reflect.ValueOf(instruments)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrInvalidMetricInstruments
+	}
+
+	var (
+		errs    []error
+		structV = value.Elem()
+		structT = structV.Type()
+	)
+
+	for i := 0; i < structT.NumField(); i++ {
+		field := structT.Field(i)
+
+		tag, ok := field.Tag.Lookup(metricInstrumentTag)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			errs = append(errs, fmt.Errorf("field %s: %w: unexported field", field.Name, ErrInvalidMetricInstrumentTag))
+			continue
+		}
+
+		opt, err := parseMetricInstrumentTag(tag)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.Name, err))
+			continue
+		}
+
+		instrument, err := newMetricInstrument(meter, field.Type, opt)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.Name, err))
+			continue
+		}
+
+		structV.Field(i).Set(reflect.ValueOf(instrument))
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseMetricInstrumentTag parse "name,unit=...,desc=..." tag and validate the name and unit
+func parseMetricInstrumentTag(tag string) (metricInstrumentOption, error) {
+	name, options, _ := strings.Cut(tag, ",")
+	opt := metricInstrumentOption{name: name}
+
+	if !metricInstrumentNameRegexp.MatchString(opt.name) {
+		return opt, fmt.Errorf("%w: %q", ErrInvalidMetricInstrumentName, opt.name)
+	}
+
+	for options != "" {
+		var option string
+
+		option, options, _ = strings.Cut(options, ",")
+		key, value, _ := strings.Cut(option, "=")
+
+		switch key {
+		case "unit":
+			opt.unit = value
+		case "desc":
+			// description is the rest of the tag so it can contain comma
+			if options != "" {
+				value += "," + options
+				options = ""
+			}
+
+			opt.description = value
+		default:
+			return opt, fmt.Errorf("%w: unknown option %q", ErrInvalidMetricInstrumentTag, key)
+		}
+	}
+
+	if !metricInstrumentUnitRegexp.MatchString(opt.unit) {
+		return opt, fmt.Errorf("%w: %q", ErrInvalidMetricInstrumentUnit, opt.unit)
+	}
+
+	return opt, nil
+}
+
+// newMetricInstrument create instrument by the field type
+func newMetricInstrument(meter metric.Meter, fieldType reflect.Type, opt metricInstrumentOption) (any, error) {
+	var (
+		name        = opt.name
+		unit        = metric.WithUnit(opt.unit)
+		description = metric.WithDescription(opt.description)
+	)
+
+	switch fieldType {
+	case reflect.TypeFor[metric.Int64Counter]():
+		return meter.Int64Counter(name, unit, description)
+	case reflect.TypeFor[metric.Float64Counter]():
+		return meter.Float64Counter(name, unit, description)
+	case reflect.TypeFor[metric.Int64UpDownCounter]():
+		return meter.Int64UpDownCounter(name, unit, description)
+	case reflect.TypeFor[metric.Float64UpDownCounter]():
+		return meter.Float64UpDownCounter(name, unit, description)
+	case reflect.TypeFor[metric.Int64Histogram]():
+		return meter.Int64Histogram(name, unit, description)
+	case reflect.TypeFor[metric.Float64Histogram]():
+		return meter.Float64Histogram(name, unit, description)
+	case reflect.TypeFor[metric.Int64Gauge]():
+		return meter.Int64Gauge(name, unit, description)
+	case reflect.TypeFor[metric.Float64Gauge]():
+		return meter.Float64Gauge(name, unit, description)
+	case reflect.TypeFor[metric.Int64ObservableCounter]():
+		return meter.Int64ObservableCounter(name, unit, description)
+	case reflect.TypeFor[metric.Float64ObservableCounter]():
+		return meter.Float64ObservableCounter(name, unit, description)
+	case reflect.TypeFor[metric.Int64ObservableUpDownCounter]():
+		return meter.Int64ObservableUpDownCounter(name, unit, description)
+	case reflect.TypeFor[metric.Float64ObservableUpDownCounter]():
+		return meter.Float64ObservableUpDownCounter(name, unit, description)
+	case reflect.TypeFor[metric.Int64ObservableGauge]():
+		return meter.Int64ObservableGauge(name, unit, description)
+	case reflect.TypeFor[metric.Float64ObservableGauge]():
+		return meter.Float64ObservableGauge(name, unit, description)
+	}
+
+	return nil, fmt.Errorf("%w: unsupported field type %s", ErrInvalidMetricInstrumentTag, fieldType)
+}
@@ -0,0 +1,14 @@
+package otel
+
+import "errors"
+
+var (
+	// ErrInvalidMetricInstruments metric instruments is not pointer to struct error
+	ErrInvalidMetricInstruments = errors.New("metric instruments must be pointer to struct")
+	// ErrInvalidMetricInstrumentName instrument name not following otel naming rule error
+	ErrInvalidMetricInstrumentName = errors.New("invalid metric instrument name")
+	// ErrInvalidMetricInstrumentUnit instrument unit not following otel unit rule error
+	ErrInvalidMetricInstrumentUnit = errors.New("invalid metric instrument unit")
+	// ErrInvalidMetricInstrumentTag unknown option or unsupported field in otel tag error
+	ErrInvalidMetricInstrumentTag = errors.New("invalid metric instrument tag")
+)
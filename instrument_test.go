@@ -0,0 +1,134 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMetricInstruments(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	var instruments struct {
+		Requests  metric.Int64Counter     `otel:"http.server.requests,unit={request},desc=Number of requests, by route"`
+		Duration  metric.Float64Histogram `otel:"http.server.duration,unit=s"`
+		Inflight  metric.Int64UpDownCounter
+		Skipped   metric.Float64Gauge         `otel:"-"`
+		Goroutine metric.Int64ObservableGauge `otel:"app.goroutine"`
+	}
+
+	if err := NewMetricInstruments(meter, &instruments); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if instruments.Inflight != nil || instruments.Skipped != nil {
+		t.Error("got instrument for field without tag or with - tag")
+	}
+
+	if instruments.Goroutine == nil {
+		t.Error("got nil observable gauge")
+	}
+
+	instruments.Requests.Add(context.Background(), 1)
+	instruments.Duration.Record(context.Background(), 0.5)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m
+		}
+	}
+
+	requests := got["http.server.requests"]
+	if requests.Unit != "{request}" || requests.Description != "Number of requests, by route" {
+		t.Errorf("got requests unit %q description %q", requests.Unit, requests.Description)
+	}
+
+	if duration := got["http.server.duration"]; duration.Unit != "s" || duration.Description != "" {
+		t.Errorf("got duration unit %q description %q", duration.Unit, duration.Description)
+	}
+}
+
+func TestNewMetricInstrumentsInvalid(t *testing.T) {
+	meter := sdkmetric.NewMeterProvider().Meter("test")
+
+	tests := []struct {
+		name        string
+		instruments any
+		want        error
+	}{
+		{name: "nil", instruments: nil, want: ErrInvalidMetricInstruments},
+		{name: "not pointer", instruments: struct{}{}, want: ErrInvalidMetricInstruments},
+		{name: "nil pointer", instruments: (*struct{})(nil), want: ErrInvalidMetricInstruments},
+		{name: "pointer to non struct", instruments: new(int), want: ErrInvalidMetricInstruments},
+		{name: "invalid name", instruments: &struct {
+			Counter metric.Int64Counter `otel:"1requests"`
+		}{}, want: ErrInvalidMetricInstrumentName},
+		{name: "empty name", instruments: &struct {
+			Counter metric.Int64Counter `otel:",unit=s"`
+		}{}, want: ErrInvalidMetricInstrumentName},
+		{name: "non ascii unit", instruments: &struct {
+			Counter metric.Int64Counter `otel:"requests,unit=µs"`
+		}{}, want: ErrInvalidMetricInstrumentUnit},
+		{name: "control character unit", instruments: &struct {
+			Counter metric.Int64Counter `otel:"requests,unit=s\x01"`
+		}{}, want: ErrInvalidMetricInstrumentUnit},
+		{name: "too long unit", instruments: &struct {
+			Counter metric.Int64Counter `otel:"requests,unit=ssssssssssssssssssssssssssssssssssssssssssssssssssssssssssssssss"`
+		}{}, want: ErrInvalidMetricInstrumentUnit},
+		{name: "unknown option", instruments: &struct {
+			Counter metric.Int64Counter `otel:"requests,scale=2"`
+		}{}, want: ErrInvalidMetricInstrumentTag},
+		{name: "unexported field", instruments: &struct {
+			counter metric.Int64Counter `otel:"requests"`
+		}{}, want: ErrInvalidMetricInstrumentTag},
+		{name: "unsupported field type", instruments: &struct {
+			Counter int64 `otel:"requests"`
+		}{}, want: ErrInvalidMetricInstrumentTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewMetricInstruments(meter, tt.instruments); !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMetricInstrumentsJoinedError(t *testing.T) {
+	var instruments struct {
+		Name    metric.Int64Counter   `otel:"1requests"`
+		Unit    metric.Float64Counter `otel:"requests,unit=s\n"`
+		Type    string                `otel:"requests"`
+		Created metric.Int64Counter   `otel:"requests"`
+	}
+
+	err := NewMetricInstruments(sdkmetric.NewMeterProvider().Meter("test"), &instruments)
+	for _, want := range []error{ErrInvalidMetricInstrumentName, ErrInvalidMetricInstrumentUnit, ErrInvalidMetricInstrumentTag} {
+		if !errors.Is(err, want) {
+			t.Errorf("got error %v, want joined %v", err, want)
+		}
+	}
+
+	for _, field := range []string{"field Name:", "field Unit:", "field Type:"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("got error %q, want %q", err, field)
+		}
+	}
+
+	if instruments.Created == nil {
+		t.Error("got nil instrument for valid field next to invalid field")
+	}
+}
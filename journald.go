@@ -0,0 +1,216 @@
+package otel
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// default journald exporter setting
+const (
+	journaldEndpointDefault       = "unix:///run/systemd/journal/socket"
+	journaldMaxFieldNameLength    = 64
+	journaldInvalidFieldPrefix    = "ATTR_"
+	journaldMessageField          = "MESSAGE"
+	journaldPriorityField         = "PRIORITY"
+	journaldSyslogIdentifierField = "SYSLOG_IDENTIFIER"
+)
+
+// journaldFieldNames journald well known field of the attribute
+var journaldFieldNames = map[attribute.Key]string{
+	semconv.CodeFilepathKey:   "CODE_FILE",
+	semconv.CodeLineNumberKey: "CODE_LINE",
+	semconv.CodeFunctionKey:   "CODE_FUNC",
+}
+
+// journaldReservedFields field set by the exporter, other attribute with the same field name is prefixed with ATTR_
+var journaldReservedFields = map[string]bool{
+	journaldMessageField:          true,
+	journaldPriorityField:         true,
+	journaldSyslogIdentifierField: true,
+	"CODE_FILE":                   true,
+	"CODE_LINE":                   true,
+	"CODE_FUNC":                   true,
+}
+
+var _ sdklog.Exporter = (*JournaldExporter)(nil)
+
+// JournaldExporterOption option for journald exporter
+type JournaldExporterOption struct {
+	// Endpoint journald native protocol socket, unix:///path/to/socket (default: unix:///run/systemd/journal/socket)
+	Endpoint string
+	// Identifier SYSLOG_IDENTIFIER field (default: service.name resource attribute)
+	Identifier string
+}
+
+// JournaldExporter log exporter that send record to systemd journald with native journal protocol,
+// body is sent as MESSAGE, severity as PRIORITY, code attribute as CODE_FILE, CODE_LINE and CODE_FUNC
+// and scope name, trace id, span id and other attributes as upper case field (e.g. http.method as HTTP_METHOD).
+// every record is sent as one datagram, record over the socket buffer size is written to unlinked file in /dev/shm
+// and the file descriptor is sent instead, like sd_journal_send
+type JournaldExporter struct {
+	opt     JournaldExporterOption
+	address string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewJournaldExporter create journald log exporter, the connection is opened on the first export
+//
+//	exporter, err := otel.NewJournaldExporter(otel.JournaldExporterOption{Identifier: "payments"})
+//	provider, err := otel.NewLogProvider(res, exporter)
+func NewJournaldExporter(opt JournaldExporterOption) (*JournaldExporter, error) {
+	if opt.Endpoint == "" {
+		opt.Endpoint = journaldEndpointDefault
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJournaldEndpoint, err)
+	}
+
+	if endpoint.Scheme != "unix" || endpoint.Path == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJournaldEndpoint, opt.Endpoint)
+	}
+
+	return &JournaldExporter{opt: opt, address: endpoint.Path}, nil
+}
+
+// Export send every record as journal entry
+func (e *JournaldExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var errs []error
+
+	for i := range records {
+		errs = append(errs, e.write(ctx, e.entry(&records[i])))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush do nothing, record is sent on export
+func (e *JournaldExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown close the connection
+func (e *JournaldExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+
+	return err
+}
+
+// write send the entry, the connection is reopened on the next write when it failed
+func (e *JournaldExporter) write(ctx context.Context, entry []byte) error {
+	if e.conn == nil {
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, "unixgram", e.address)
+		if err != nil {
+			return err
+		}
+
+		e.conn = conn
+	}
+
+	_, err := e.conn.Write(entry)
+	if err != nil && journaldEntryTooLarge(err) {
+		err = writeJournaldEntryFile(e.address, entry)
+	}
+
+	if err != nil {
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+
+	return err
+}
+
+// entry encode the record as journal native protocol "FIELD=value\n" entry,
+// value with new line is encoded as "FIELD\n" followed by 64 bit little endian length, the value and "\n"
+func (e *JournaldExporter) entry(record *sdklog.Record) []byte {
+	var entry []byte
+
+	identifier := e.opt.Identifier
+	if identifier == "" {
+		identifier = logRecordServiceName(record)
+	}
+
+	entry = appendJournaldField(entry, journaldMessageField, record.Body().String())
+	entry = appendJournaldField(entry, journaldPriorityField, strconv.Itoa(syslogSeverity(record.Severity())))
+
+	if identifier != "" {
+		entry = appendJournaldField(entry, journaldSyslogIdentifierField, identifier)
+	}
+
+	for _, attr := range logRecordFields(record) {
+		name, ok := journaldFieldNames[attr.Key]
+		if !ok {
+			name = journaldFieldName(string(attr.Key))
+		}
+
+		if name != "" {
+			entry = appendJournaldField(entry, name, attr.Value.Emit())
+		}
+	}
+
+	return entry
+}
+
+// appendJournaldField append the field with binary safe encoding when the value has new line
+func appendJournaldField(entry []byte, name, value string) []byte {
+	if !strings.Contains(value, "\n") {
+		return append(append(append(entry, name...), '='), value+"\n"...)
+	}
+
+	entry = append(append(entry, name...), '\n')
+	entry = binary.LittleEndian.AppendUint64(entry, uint64(len(value)))
+
+	return append(entry, value+"\n"...)
+}
+
+// journaldFieldName returns upper case field name of A-Z, 0-9 and underscore up to 64 character,
+// leading underscore is trimmed, leading digit and the reserved field name is prefixed with ATTR_
+func journaldFieldName(key string) string {
+	name := strings.TrimLeft(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+
+		return '_'
+	}, key), "_")
+
+	if name != "" && (name[0] >= '0' && name[0] <= '9' || journaldReservedFields[name]) {
+		name = journaldInvalidFieldPrefix + name
+	}
+
+	if len(name) > journaldMaxFieldNameLength {
+		name = name[:journaldMaxFieldNameLength]
+	}
+
+	return name
+}
@@ -0,0 +1,48 @@
+package otel
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// journaldEntryFileDir directory of the entry file, journald only accept file from /dev/shm, /tmp and /var/tmp
+const journaldEntryFileDir = "/dev/shm"
+
+// journaldEntryTooLarge returns true when the datagram is over the socket buffer size
+func journaldEntryTooLarge(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)
+}
+
+// writeJournaldEntryFile write the entry to unlinked file and send the file descriptor to journald,
+// file descriptor can't be sent with connected datagram socket so new unbound socket is used
+func writeJournaldEntryFile(address string, entry []byte) error {
+	file, err := os.CreateTemp(journaldEntryFileDir, "journal.*")
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	err = os.Remove(file.Name())
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(entry)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	_, _, err = conn.WriteMsgUnix(nil, syscall.UnixRights(int(file.Fd())), &net.UnixAddr{Name: address, Net: "unixgram"})
+
+	return err
+}
@@ -0,0 +1,70 @@
+package otel
+
+import (
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestJournaldExporterEntryFile(t *testing.T) {
+	if _, err := os.Stat(journaldEntryFileDir); err != nil {
+		t.Skipf("%s is not available: %v", journaldEntryFileDir, err)
+	}
+
+	conn := listenTestUnixgram(t)
+
+	exporter, err := NewJournaldExporter(JournaldExporterOption{Endpoint: "unix://" + conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := strings.Repeat("x", 4<<20)
+
+	emitTestLogRecord(t, exporter, log.SeverityInfo, body)
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	oob := make([]byte, syscall.CmsgSpace(4))
+
+	n, oobn, _, _, err := conn.ReadMsgUnix(make([]byte, 1024), oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 0 {
+		t.Errorf("got %d bytes datagram, want only file descriptor", n)
+	}
+
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("got control message %v %v, want one", messages, err)
+	}
+
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("got file descriptor %v %v, want one", fds, err)
+	}
+
+	file := os.NewFile(uintptr(fds[0]), "entry")
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := io.ReadAll(io.NewSectionReader(file, 0, info.Size()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := parseTestJournaldEntry(t, entry)
+	if got := fields["MESSAGE"]; len(got) != 1 || got[0] != body {
+		t.Errorf("MESSAGE field of %d bytes is not sent in the file", len(body))
+	}
+}
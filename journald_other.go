@@ -0,0 +1,18 @@
+//go:build !linux
+
+package otel
+
+import (
+	"errors"
+	"syscall"
+)
+
+// journaldEntryTooLarge returns true when the datagram is over the socket buffer size
+func journaldEntryTooLarge(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE)
+}
+
+// writeJournaldEntryFile returns the original error, journald only run on linux
+func writeJournaldEntryFile(string, []byte) error {
+	return syscall.EMSGSIZE
+}
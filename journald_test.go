@@ -0,0 +1,117 @@
+package otel
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// parseTestJournaldEntry decode journal native protocol entry, field may be repeated
+func parseTestJournaldEntry(t *testing.T, entry []byte) map[string][]string {
+	t.Helper()
+
+	fields := make(map[string][]string)
+
+	for len(entry) > 0 {
+		end := strings.IndexAny(string(entry), "=\n")
+		if end < 0 {
+			t.Fatalf("invalid entry %q", entry)
+		}
+
+		name := string(entry[:end])
+
+		if entry[end] == '=' {
+			value, rest, ok := strings.Cut(string(entry[end+1:]), "\n")
+			if !ok {
+				t.Fatalf("field %s is not terminated", name)
+			}
+
+			fields[name] = append(fields[name], value)
+			entry = []byte(rest)
+
+			continue
+		}
+
+		entry = entry[end+1:]
+		size := binary.LittleEndian.Uint64(entry)
+		entry = entry[8:]
+
+		if entry[size] != '\n' {
+			t.Fatalf("binary field %s is not terminated", name)
+		}
+
+		fields[name] = append(fields[name], string(entry[:size]))
+		entry = entry[size+1:]
+	}
+
+	return fields
+}
+
+func TestJournaldExporterEntry(t *testing.T) {
+	conn := listenTestUnixgram(t)
+
+	exporter, err := NewJournaldExporter(JournaldExporterOption{Endpoint: "unix://" + conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emitTestLogRecord(t, exporter, log.SeverityWarn, "first line\nsecond line",
+		log.String("message", "attribute message"),
+		log.String("priority", "high"),
+		log.String("syslog.identifier", "other"),
+		log.String("code.filepath", "main.go"),
+		log.Int("code.lineno", 42),
+		log.String("http.method", "GET"),
+		log.String("9lives", "cat"),
+	)
+
+	fields := parseTestJournaldEntry(t, []byte(readTestPacket(t, conn)))
+
+	want := map[string]string{
+		"MESSAGE":                "first line\nsecond line",
+		"PRIORITY":               "4",
+		"SYSLOG_IDENTIFIER":      "payments",
+		"OTEL_SCOPE_NAME":        "test",
+		"ATTR_MESSAGE":           "attribute message",
+		"ATTR_PRIORITY":          "high",
+		"ATTR_SYSLOG_IDENTIFIER": "other",
+		"CODE_FILE":              "main.go",
+		"CODE_LINE":              "42",
+		"HTTP_METHOD":            "GET",
+		"ATTR_9LIVES":            "cat",
+	}
+
+	for name, value := range want {
+		if got := fields[name]; len(got) != 1 || got[0] != value {
+			t.Errorf("field %s = %q, want %q", name, got, value)
+		}
+	}
+
+	if len(fields) != len(want) {
+		t.Errorf("got fields %v, want %d fields", fields, len(want))
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	tests := map[string]string{
+		"http.method":           "HTTP_METHOD",
+		"_private":              "PRIVATE",
+		"message":               "ATTR_MESSAGE",
+		"code_file":             "ATTR_CODE_FILE",
+		"1st":                   "ATTR_1ST",
+		strings.Repeat("a", 70): strings.Repeat("A", 64),
+		"ümlaut":                "MLAUT",
+	}
+
+	for key, want := range tests {
+		if got := journaldFieldName(key); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	if got := journaldFieldName("message" + strings.Repeat("x", 70)); len(got) != journaldMaxFieldNameLength {
+		t.Errorf("field name %s is over %d character", got, journaldMaxFieldNameLength)
+	}
+}
@@ -0,0 +1,674 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+)
+
+// metricOverflowKey attribute of the series that hold the attribute set over the series limit
+const metricOverflowKey = attribute.Key("otel.metric.overflow")
+
+var metricOverflowAttributes = attribute.NewSet(metricOverflowKey.Bool(true))
+
+// metric series overflow self metric
+const (
+	seriesOverflowMetric    = "otelgo.metric.series.overflow"
+	seriesOverflowMeterKey  = attribute.Key("otelgo.metric.meter")
+	seriesOverflowMetricKey = attribute.Key("otelgo.metric.name")
+)
+
+// SeriesLimitDisabled series limit of unlimited metric stream,
+// for MetricSeriesLimit.Default, MetricViewConfig.SeriesLimit and WithMetricSeriesLimit
+const SeriesLimitDisabled = -1
+
+// MetricSeriesLimit limit the number of series exported per metric stream,
+// attribute set over the limit is folded into single series with otel.metric.overflow=true attribute.
+// the limit include the overflow series, so limit 100 keep 99 attribute set and the overflow series.
+// attribute set missing from an export is expired, so delta stream admit new attribute set on the next export.
+// the number of folded attribute set is recorded to otelgo.metric.series.overflow gauge
+// of the global meter provider and ErrMetricSeriesLimit is sent to otel.Handle once per metric stream.
+//
+// the limit is only applied on export and prometheus gather, it is not a cardinality limit of the metric sdk
+// that still aggregate every attribute set between export. use the experimental OTEL_GO_X_CARDINALITY_LIMIT
+// env of the sdk to bound the sdk memory
+type MetricSeriesLimit struct {
+	// Default limit for every metric stream, SeriesLimitDisabled is unlimited.
+	// InitMetricProvider and NewProviders set 0 from OTEL_METRICS_SERIES_LIMIT (default: 2000)
+	Default int
+	// Views per stream limit from MetricViewConfig.SeriesLimit, the first matched view is used,
+	// view with 0 limit is skipped and view with SeriesLimitDisabled disable the limit of the stream.
+	// stream is matched by Meter and Name, or Instrument glob when Name is empty
+	Views []MetricViewConfig
+}
+
+func (l MetricSeriesLimit) enabled() bool {
+	if l.Default > 0 {
+		return true
+	}
+
+	for _, view := range l.Views {
+		if view.SeriesLimit > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// seriesLimiter per metric stream limit state shared by exporter and prometheus gatherer wrapper
+type seriesLimiter struct {
+	limit    MetricSeriesLimit
+	patterns []*regexp.Regexp
+
+	mu      sync.Mutex
+	streams map[metricStreamKey]*metricStreamLimit
+
+	overflow metric.Int64Gauge
+}
+
+type metricStreamKey struct {
+	scope instrumentation.Scope
+	name  string
+}
+
+type metricStreamLimit struct {
+	limit int
+	// admitted attribute set of the last apply
+	admitted map[attribute.Distinct]struct{}
+	// current admitted attribute set of the running apply
+	current map[attribute.Distinct]struct{}
+	// folded attribute set in the last apply
+	folded     int
+	lastFolded int
+	warned     bool
+}
+
+// newSeriesLimiter create limiter, view Name or Instrument glob is followed by the name suffix regexp
+func newSeriesLimiter(limit MetricSeriesLimit, nameFunc func(string) string, suffix string) *seriesLimiter {
+	patterns := make([]*regexp.Regexp, len(limit.Views))
+	for i, view := range limit.Views {
+		// view name can't be glob so it is matched exactly
+		name := view.Instrument
+		if view.Name != "" {
+			name = view.Name
+		}
+
+		patterns[i] = globRegexp(nameFunc(name), suffix)
+	}
+
+	return &seriesLimiter{
+		limit:    limit,
+		patterns: patterns,
+		streams:  make(map[metricStreamKey]*metricStreamLimit),
+		overflow: newSeriesOverflowGauge(),
+	}
+}
+
+// newSeriesOverflowGauge create the overflow self metric on the global meter provider
+func newSeriesOverflowGauge() metric.Int64Gauge {
+	gauge, err := otel.GetMeterProvider().Meter(instrumentationName).Int64Gauge(seriesOverflowMetric,
+		metric.WithDescription("Number of attribute set folded into otel.metric.overflow series in the last export"),
+		metric.WithUnit("{attribute_set}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return gauge
+}
+
+func (l *seriesLimiter) stream(scope instrumentation.Scope, name string) *metricStreamLimit {
+	key := metricStreamKey{scope: scope, name: name}
+
+	stream, ok := l.streams[key]
+	if !ok {
+		stream = &metricStreamLimit{
+			limit:    l.streamLimit(scope, name),
+			admitted: make(map[attribute.Distinct]struct{}),
+		}
+		l.streams[key] = stream
+	}
+
+	return stream
+}
+
+func (l *seriesLimiter) streamLimit(scope instrumentation.Scope, name string) int {
+	for i, view := range l.limit.Views {
+		if view.SeriesLimit == 0 || (view.Meter != "" && view.Meter != scope.Name) {
+			continue
+		}
+
+		if l.patterns[i].MatchString(name) {
+			return view.SeriesLimit
+		}
+	}
+
+	return l.limit.Default
+}
+
+// seriesLimitExporter metric exporter wrapper that apply series limit before export
+type seriesLimitExporter struct {
+	sdkmetric.Exporter
+
+	limiter *seriesLimiter
+}
+
+func newSeriesLimitExporter(exporter sdkmetric.Exporter, limit MetricSeriesLimit) *seriesLimitExporter {
+	return &seriesLimitExporter{
+		Exporter: exporter,
+		limiter:  newSeriesLimiter(limit, func(name string) string { return name }, ""),
+	}
+}
+
+// Export fold the attribute set over the limit then export the metric
+func (e *seriesLimitExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.limiter.mu.Lock()
+	for i := range rm.ScopeMetrics {
+		scopeMetrics := &rm.ScopeMetrics[i]
+
+		for j := range scopeMetrics.Metrics {
+			m := &scopeMetrics.Metrics[j]
+
+			stream := e.limiter.stream(scopeMetrics.Scope, m.Name)
+			if stream.limit > 0 {
+				m.Data = stream.apply(m.Data)
+				stream.report(ctx, e.limiter.overflow, scopeMetrics.Scope.Name, m.Name)
+			}
+		}
+	}
+	e.limiter.mu.Unlock()
+
+	return e.Exporter.Export(ctx, rm)
+}
+
+// report record the folded attribute set of the last apply and handle ErrMetricSeriesLimit once when the stream overflow
+func (s *metricStreamLimit) report(ctx context.Context, gauge metric.Int64Gauge, scope, name string) {
+	// record zero once the stream stop overflowing so the gauge don't keep the last value
+	if s.folded == 0 && s.lastFolded == 0 {
+		return
+	}
+
+	s.lastFolded = s.folded
+
+	if gauge != nil {
+		gauge.Record(ctx, int64(s.folded), metric.WithAttributes(
+			seriesOverflowMeterKey.String(scope),
+			seriesOverflowMetricKey.String(name),
+		))
+	}
+
+	if s.folded == 0 || s.warned {
+		return
+	}
+
+	s.warned = true
+	otel.Handle(fmt.Errorf("%w: metric %s of meter %s, limit %d", ErrMetricSeriesLimit, name, scope, s.limit))
+}
+
+// apply fold the data point over the limit into overflow data point
+func (s *metricStreamLimit) apply(data metricdata.Aggregation) metricdata.Aggregation {
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, dataPointAttributes[int64], mergeSumDataPoint[int64])
+		return d
+	case metricdata.Sum[float64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, dataPointAttributes[float64], mergeSumDataPoint[float64])
+		return d
+	case metricdata.Gauge[int64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, dataPointAttributes[int64], mergeGaugeDataPoint[int64])
+		return d
+	case metricdata.Gauge[float64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, dataPointAttributes[float64], mergeGaugeDataPoint[float64])
+		return d
+	case metricdata.Histogram[int64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, histogramAttributes[int64], mergeHistogramDataPoint[int64])
+		return d
+	case metricdata.Histogram[float64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, histogramAttributes[float64], mergeHistogramDataPoint[float64])
+		return d
+	case metricdata.ExponentialHistogram[int64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, exponentialHistogramAttributes[int64], mergeExponentialHistogramDataPoint[int64])
+		return d
+	case metricdata.ExponentialHistogram[float64]:
+		d.DataPoints = limitDataPoints(s, d.DataPoints, exponentialHistogramAttributes[float64], mergeExponentialHistogramDataPoint[float64])
+		return d
+	}
+
+	return data
+}
+
+// begin start apply, attribute set is admitted again from the attribute set of the last apply
+func (s *metricStreamLimit) begin() {
+	s.folded = 0
+	s.current = make(map[attribute.Distinct]struct{}, len(s.admitted))
+}
+
+// keep returns true and admit the attribute set when it is admitted in the last apply
+func (s *metricStreamLimit) keep(distinct attribute.Distinct) bool {
+	if _, ok := s.admitted[distinct]; !ok {
+		return false
+	}
+
+	s.current[distinct] = struct{}{}
+
+	return true
+}
+
+// admit returns true when the attribute set is admitted or there is room for new attribute set,
+// the overflow series take the last room of the limit
+func (s *metricStreamLimit) admit(distinct attribute.Distinct) bool {
+	if _, ok := s.current[distinct]; ok {
+		return true
+	}
+
+	if len(s.current) >= s.limit-1 {
+		s.folded++
+		return false
+	}
+
+	s.current[distinct] = struct{}{}
+
+	return true
+}
+
+// end finish apply, attribute set that is not in the apply is expired
+func (s *metricStreamLimit) end() {
+	s.admitted, s.current = s.current, nil
+}
+
+// limitDataPoints keep attribute set admitted in the last apply, admit new attribute set until limit - 1
+// and merge the rest into the overflow data point
+func limitDataPoints[P any](s *metricStreamLimit, points []P, attrs func(p *P) *attribute.Set, merge func(overflow *P, p P)) []P {
+	s.begin()
+	defer s.end()
+
+	// keep the admitted attribute set first so new attribute set doesn't take their room
+	kept := make([]bool, len(points))
+	for i := range points {
+		kept[i] = s.keep(attrs(&points[i]).Equivalent())
+	}
+
+	var (
+		limited  = points[:0:0]
+		overflow *P
+	)
+
+	for i, point := range points {
+		if kept[i] || s.admit(attrs(&point).Equivalent()) {
+			limited = append(limited, point)
+			continue
+		}
+
+		if overflow == nil {
+			// merge into empty overflow data point so the original data point is not modified
+			var empty P
+			overflow = &empty
+			*attrs(overflow) = metricOverflowAttributes
+		}
+
+		merge(overflow, point)
+	}
+
+	if overflow != nil {
+		limited = append(limited, *overflow)
+	}
+
+	return limited
+}
+
+func dataPointAttributes[N int64 | float64](p *metricdata.DataPoint[N]) *attribute.Set {
+	return &p.Attributes
+}
+
+func histogramAttributes[N int64 | float64](p *metricdata.HistogramDataPoint[N]) *attribute.Set {
+	return &p.Attributes
+}
+
+func exponentialHistogramAttributes[N int64 | float64](p *metricdata.ExponentialHistogramDataPoint[N]) *attribute.Set {
+	return &p.Attributes
+}
+
+func mergeSumDataPoint[N int64 | float64](overflow *metricdata.DataPoint[N], p metricdata.DataPoint[N]) {
+	overflow.StartTime = minTime(overflow.StartTime, p.StartTime)
+	overflow.Time = maxTime(overflow.Time, p.Time)
+	overflow.Value += p.Value
+	overflow.Exemplars = append(overflow.Exemplars, p.Exemplars...)
+}
+
+func mergeGaugeDataPoint[N int64 | float64](overflow *metricdata.DataPoint[N], p metricdata.DataPoint[N]) {
+	overflow.StartTime = minTime(overflow.StartTime, p.StartTime)
+	if overflow.Time.IsZero() || p.Time.After(overflow.Time) {
+		overflow.Value = p.Value
+		overflow.Time = p.Time
+	}
+
+	overflow.Exemplars = append(overflow.Exemplars, p.Exemplars...)
+}
+
+func mergeHistogramDataPoint[N int64 | float64](overflow *metricdata.HistogramDataPoint[N], p metricdata.HistogramDataPoint[N]) {
+	// same instrument always has same bounds
+	if overflow.Count == 0 && overflow.BucketCounts == nil {
+		overflow.Bounds = p.Bounds
+		overflow.BucketCounts = make([]uint64, len(p.BucketCounts))
+	}
+
+	overflow.StartTime = minTime(overflow.StartTime, p.StartTime)
+	overflow.Time = maxTime(overflow.Time, p.Time)
+	overflow.Count += p.Count
+	overflow.Sum += p.Sum
+	overflow.Min = mergeExtrema(overflow.Min, p.Min, func(a, b N) bool { return a < b })
+	overflow.Max = mergeExtrema(overflow.Max, p.Max, func(a, b N) bool { return a > b })
+	overflow.Exemplars = append(overflow.Exemplars, p.Exemplars...)
+
+	for i := range overflow.BucketCounts {
+		if i < len(p.BucketCounts) {
+			overflow.BucketCounts[i] += p.BucketCounts[i]
+		}
+	}
+}
+
+func mergeExponentialHistogramDataPoint[N int64 | float64](overflow *metricdata.ExponentialHistogramDataPoint[N], p metricdata.ExponentialHistogramDataPoint[N]) {
+	if overflow.Time.IsZero() {
+		overflow.Scale = p.Scale
+		overflow.ZeroThreshold = p.ZeroThreshold
+	}
+
+	overflow.StartTime = minTime(overflow.StartTime, p.StartTime)
+	overflow.Time = maxTime(overflow.Time, p.Time)
+	overflow.Count += p.Count
+	overflow.Sum += p.Sum
+	overflow.ZeroCount += p.ZeroCount
+	overflow.ZeroThreshold = max(overflow.ZeroThreshold, p.ZeroThreshold)
+	overflow.Min = mergeExtrema(overflow.Min, p.Min, func(a, b N) bool { return a < b })
+	overflow.Max = mergeExtrema(overflow.Max, p.Max, func(a, b N) bool { return a > b })
+	overflow.Exemplars = append(overflow.Exemplars, p.Exemplars...)
+
+	// downscale both data point to the lowest scale before merging the bucket
+	scale := min(overflow.Scale, p.Scale)
+	overflow.PositiveBucket = mergeExponentialBucket(overflow.PositiveBucket, overflow.Scale-scale, p.PositiveBucket, p.Scale-scale)
+	overflow.NegativeBucket = mergeExponentialBucket(overflow.NegativeBucket, overflow.Scale-scale, p.NegativeBucket, p.Scale-scale)
+	overflow.Scale = scale
+}
+
+// mergeExponentialBucket merge bucket a and b after downscale by the shift
+func mergeExponentialBucket(a metricdata.ExponentialBucket, shiftA int32, b metricdata.ExponentialBucket, shiftB int32) metricdata.ExponentialBucket {
+	counts := make(map[int32]uint64, len(a.Counts)+len(b.Counts))
+
+	for i, count := range a.Counts {
+		counts[(a.Offset+int32(i))>>shiftA] += count
+	}
+
+	for i, count := range b.Counts {
+		counts[(b.Offset+int32(i))>>shiftB] += count
+	}
+
+	if len(counts) == 0 {
+		return metricdata.ExponentialBucket{}
+	}
+
+	first, last := int32(0), int32(0)
+	initialized := false
+
+	for index := range counts {
+		if !initialized || index < first {
+			first = index
+		}
+
+		if !initialized || index > last {
+			last = index
+		}
+
+		initialized = true
+	}
+
+	bucket := metricdata.ExponentialBucket{
+		Offset: first,
+		Counts: make([]uint64, last-first+1),
+	}
+
+	for index, count := range counts {
+		bucket.Counts[index-first] = count
+	}
+
+	return bucket
+}
+
+func mergeExtrema[N int64 | float64](a, b metricdata.Extrema[N], better func(a, b N) bool) metricdata.Extrema[N] {
+	valueA, okA := a.Value()
+	valueB, okB := b.Value()
+
+	if !okB || (okA && !better(valueB, valueA)) {
+		return a
+	}
+
+	return metricdata.NewExtrema(valueB)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
+
+// globRegexp convert instrument glob pattern with "*" and "?" to regexp followed by the suffix regexp,
+// empty pattern match all
+func globRegexp(pattern, suffix string) *regexp.Regexp {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	pattern = regexp.QuoteMeta(pattern)
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+
+	return regexp.MustCompile("^" + pattern + suffix + "$")
+}
+
+// prometheus label of the otel scope and the overflow series
+const (
+	prometheusScopeNameLabel    = "otel_scope_name"
+	prometheusScopeVersionLabel = "otel_scope_version"
+	prometheusOverflowLabel     = "otel_metric_overflow"
+)
+
+// prometheusNameSuffix unit and _total suffix appended by prometheus exporter to the metric name
+const prometheusNameSuffix = "(_[a-z]+)*"
+
+// prometheusUnlimitedFamilies resource and scope info family is not limited
+var prometheusUnlimitedFamilies = map[string]bool{"target_info": true, "otel_scope_info": true}
+
+var prometheusInvalidNameChar = regexp.MustCompile(`[^a-zA-Z0-9_:*?]`)
+
+// seriesLimitGatherer prometheus gatherer wrapper that apply series limit on gather
+type seriesLimitGatherer struct {
+	gatherer promclient.Gatherer
+	limiter  *seriesLimiter
+}
+
+// NewSeriesLimitGatherer wrap prometheus gatherer to apply series limit on gather, for prometheus exporter.
+// view is matched to the metric family by the prometheus sanitized instrument name followed by unit and _total suffix,
+// the overflow series has otel_metric_overflow="true" label
+//
+//	handler := promhttp.HandlerFor(otel.NewSeriesLimitGatherer(registry, otel.MetricSeriesLimit{Default: 2000}), promhttp.HandlerOpts{})
+func NewSeriesLimitGatherer(gatherer promclient.Gatherer, limit MetricSeriesLimit) promclient.Gatherer {
+	return &seriesLimitGatherer{
+		gatherer: gatherer,
+		limiter: newSeriesLimiter(limit, func(name string) string {
+			return prometheusInvalidNameChar.ReplaceAllString(name, "_")
+		}, prometheusNameSuffix),
+	}
+}
+
+// Gather fold the metric over the limit of each metric family
+func (g *seriesLimitGatherer) Gather() ([]*dto.MetricFamily, error) {
+	// gatherer can return partial result with error
+	families, err := g.gatherer.Gather()
+
+	g.limiter.mu.Lock()
+	defer g.limiter.mu.Unlock()
+
+	for _, family := range families {
+		if !prometheusUnlimitedFamilies[family.GetName()] {
+			family.Metric = g.apply(family)
+		}
+	}
+
+	return families, err
+}
+
+func (g *seriesLimitGatherer) apply(family *dto.MetricFamily) []*dto.Metric {
+	type limitMetric struct {
+		stream *metricStreamLimit
+		attrs  attribute.Distinct
+		kept   bool
+	}
+
+	var (
+		limited   = family.Metric[:0:0]
+		metrics   = make([]limitMetric, len(family.Metric))
+		overflows = make(map[*metricStreamLimit]*dto.Metric)
+		scopes    = make(map[*metricStreamLimit]instrumentation.Scope)
+	)
+
+	// keep the attribute set admitted in the last gather first so new attribute set doesn't take their room
+	for i, m := range family.Metric {
+		scope, attrs := prometheusMetricLabels(m)
+
+		stream := g.limiter.stream(scope, family.GetName())
+		if stream.limit <= 0 {
+			continue
+		}
+
+		if _, ok := scopes[stream]; !ok {
+			scopes[stream] = scope
+			stream.begin()
+		}
+
+		metrics[i] = limitMetric{stream: stream, attrs: attrs.Equivalent()}
+		metrics[i].kept = stream.keep(metrics[i].attrs)
+	}
+
+	for i, m := range family.Metric {
+		stream := metrics[i].stream
+		if stream == nil || metrics[i].kept || stream.admit(metrics[i].attrs) {
+			limited = append(limited, m)
+			continue
+		}
+
+		overflow, ok := overflows[stream]
+		if !ok {
+			overflow = newPrometheusOverflowMetric(scopes[stream])
+			overflows[stream] = overflow
+		}
+
+		mergePrometheusMetric(overflow, m)
+	}
+
+	for stream, scope := range scopes {
+		if overflow, ok := overflows[stream]; ok {
+			limited = append(limited, overflow)
+		}
+
+		stream.end()
+		stream.report(context.Background(), g.limiter.overflow, scope.Name, family.GetName())
+	}
+
+	return limited
+}
+
+// prometheusMetricLabels split otel scope label and the attribute label of the metric
+func prometheusMetricLabels(m *dto.Metric) (instrumentation.Scope, attribute.Set) {
+	var (
+		scope instrumentation.Scope
+		attrs = make([]attribute.KeyValue, 0, len(m.GetLabel()))
+	)
+
+	for _, label := range m.GetLabel() {
+		switch label.GetName() {
+		case prometheusScopeNameLabel:
+			scope.Name = label.GetValue()
+		case prometheusScopeVersionLabel:
+			scope.Version = label.GetValue()
+		default:
+			attrs = append(attrs, attribute.String(label.GetName(), label.GetValue()))
+		}
+	}
+
+	return scope, attribute.NewSet(attrs...)
+}
+
+// newPrometheusOverflowMetric create empty overflow metric of the scope
+func newPrometheusOverflowMetric(scope instrumentation.Scope) *dto.Metric {
+	labels := []*dto.LabelPair{{Name: proto.String(prometheusOverflowLabel), Value: proto.String("true")}}
+
+	if scope.Name != "" {
+		labels = append(labels,
+			&dto.LabelPair{Name: proto.String(prometheusScopeNameLabel), Value: proto.String(scope.Name)},
+			&dto.LabelPair{Name: proto.String(prometheusScopeVersionLabel), Value: proto.String(scope.Version)},
+		)
+	}
+
+	return &dto.Metric{Label: labels}
+}
+
+// mergePrometheusMetric merge the metric into the overflow metric, exemplar is dropped
+func mergePrometheusMetric(overflow, m *dto.Metric) {
+	switch {
+	case m.Counter != nil:
+		overflow.Counter = &dto.Counter{Value: proto.Float64(overflow.GetCounter().GetValue() + m.GetCounter().GetValue())}
+	case m.Gauge != nil:
+		overflow.Gauge = &dto.Gauge{Value: proto.Float64(m.GetGauge().GetValue())}
+	case m.Untyped != nil:
+		overflow.Untyped = &dto.Untyped{Value: proto.Float64(m.GetUntyped().GetValue())}
+	case m.Summary != nil:
+		// quantile can't be merged
+		overflow.Summary = &dto.Summary{
+			SampleCount: proto.Uint64(overflow.GetSummary().GetSampleCount() + m.GetSummary().GetSampleCount()),
+			SampleSum:   proto.Float64(overflow.GetSummary().GetSampleSum() + m.GetSummary().GetSampleSum()),
+		}
+	case m.Histogram != nil:
+		if overflow.Histogram == nil {
+			// same instrument always has same bucket bounds
+			overflow.Histogram = &dto.Histogram{SampleCount: proto.Uint64(0), SampleSum: proto.Float64(0)}
+			for _, bucket := range m.GetHistogram().GetBucket() {
+				overflow.Histogram.Bucket = append(overflow.Histogram.Bucket, &dto.Bucket{
+					UpperBound:      proto.Float64(bucket.GetUpperBound()),
+					CumulativeCount: proto.Uint64(0),
+				})
+			}
+		}
+
+		overflow.Histogram.SampleCount = proto.Uint64(overflow.Histogram.GetSampleCount() + m.GetHistogram().GetSampleCount())
+		overflow.Histogram.SampleSum = proto.Float64(overflow.Histogram.GetSampleSum() + m.GetHistogram().GetSampleSum())
+
+		for i, bucket := range m.GetHistogram().GetBucket() {
+			if i < len(overflow.Histogram.Bucket) && overflow.Histogram.Bucket[i].GetUpperBound() == bucket.GetUpperBound() {
+				overflow.Histogram.Bucket[i].CumulativeCount = proto.Uint64(overflow.Histogram.Bucket[i].GetCumulativeCount() + bucket.GetCumulativeCount())
+			}
+		}
+	}
+}
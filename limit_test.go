@@ -0,0 +1,246 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryMetricExporter metric exporter that keep the last exported metric
+type memoryMetricExporter struct {
+	sdkmetric.Exporter
+
+	last *metricdata.ResourceMetrics
+}
+
+func (e *memoryMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	e.last = rm
+	return nil
+}
+
+// captureTestErrors set global error handler that keep the handled error until the test end
+func captureTestErrors(t *testing.T) func() []error {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		errs   []error
+		before = otel.GetErrorHandler()
+	)
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(before) })
+
+	return func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]error(nil), errs...)
+	}
+}
+
+// exportTestSeriesLimit export requests counter with user attribute and returns the exported user, overflow as "_overflow"
+func exportTestSeriesLimit(t *testing.T, exporter sdkmetric.Exporter, memory *memoryMetricExporter, users ...string) []string {
+	t.Helper()
+
+	points := make([]metricdata.DataPoint[int64], 0, len(users))
+	for _, user := range users {
+		points = append(points, testSnapshotPoint(1, attribute.String("user", user)))
+	}
+
+	rm := testSnapshotResourceMetrics(metricdata.ScopeMetrics{
+		Scope:   instrumentation.Scope{Name: "a"},
+		Metrics: []metricdata.Metrics{testSnapshotCounter("requests", "", points...)},
+	})
+
+	if err := exporter.Export(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for _, point := range memory.last.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints {
+		if _, ok := point.Attributes.Value(metricOverflowKey); ok {
+			got = append(got, "_overflow")
+			continue
+		}
+
+		user, _ := point.Attributes.Value("user")
+		got = append(got, user.AsString())
+	}
+
+	return got
+}
+
+func TestSeriesLimitExporter(t *testing.T) {
+	points := make([]metricdata.DataPoint[int64], 0, 5)
+	for _, user := range []string{"a", "b", "c", "d", "e"} {
+		points = append(points, testSnapshotPoint(1, attribute.String("user", user)))
+	}
+
+	tests := []struct {
+		name  string
+		limit MetricSeriesLimit
+		want  int
+	}{
+		{name: "default limit", limit: MetricSeriesLimit{Default: 3}, want: 3},
+		{name: "disabled default", limit: MetricSeriesLimit{Default: SeriesLimitDisabled}, want: 5},
+		{name: "view limit", limit: MetricSeriesLimit{Default: SeriesLimitDisabled, Views: []MetricViewConfig{
+			{Instrument: "requests", SeriesLimit: 2},
+		}}, want: 2},
+		{name: "disabled view", limit: MetricSeriesLimit{Default: 3, Views: []MetricViewConfig{
+			{Instrument: "requests", SeriesLimit: SeriesLimitDisabled},
+		}}, want: 5},
+		{name: "unset view use default", limit: MetricSeriesLimit{Default: 3, Views: []MetricViewConfig{
+			{Instrument: "requests"},
+		}}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryMetricExporter{}
+			exporter := newSeriesLimitExporter(memory, tt.limit)
+
+			rm := testSnapshotResourceMetrics(metricdata.ScopeMetrics{
+				Scope:   instrumentation.Scope{Name: "a"},
+				Metrics: []metricdata.Metrics{testSnapshotCounter("requests", "", points...)},
+			})
+
+			if err := exporter.Export(context.Background(), rm); err != nil {
+				t.Fatal(err)
+			}
+
+			got := memory.last.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints
+			if len(got) != tt.want {
+				t.Errorf("got %d data points, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSeriesLimitFromEnv(t *testing.T) {
+	tests := map[string]int{
+		"":     seriesLimitEnvDefault,
+		"500":  500,
+		"0":    SeriesLimitDisabled,
+		"-100": SeriesLimitDisabled,
+	}
+
+	for env, want := range tests {
+		t.Setenv(seriesLimitEnv, env)
+
+		got, err := getSeriesLimitFromEnv()
+		if err != nil || got != want {
+			t.Errorf("env %q got %d, %v, want %d", env, got, err, want)
+		}
+	}
+}
+
+func TestSeriesLimitExporterExpire(t *testing.T) {
+	errs := captureTestErrors(t)
+
+	memory := &memoryMetricExporter{}
+	exporter := newSeriesLimitExporter(memory, MetricSeriesLimit{Default: 3})
+
+	tests := []struct {
+		name  string
+		users []string
+		want  []string
+	}{
+		{name: "admit until limit - 1", users: []string{"a", "b", "c"}, want: []string{"a", "b", "_overflow"}},
+		// delta export only has the attribute set recorded since the last export
+		{name: "missing attribute set is expired", users: []string{"c", "d"}, want: []string{"c", "d"}},
+		{name: "admitted attribute set is kept first", users: []string{"e", "d", "c"}, want: []string{"d", "c", "_overflow"}},
+	}
+
+	for _, tt := range tests {
+		got := exportTestSeriesLimit(t, exporter, memory, tt.users...)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// the overflow is handled once per metric stream
+	if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrMetricSeriesLimit) {
+		t.Errorf("got handled errors %v, want one %v", got, ErrMetricSeriesLimit)
+	}
+}
+
+func TestSeriesLimitGatherer(t *testing.T) {
+	captureTestErrors(t)
+
+	var users []string
+
+	gatherer := NewSeriesLimitGatherer(promclient.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		family := &dto.MetricFamily{Name: proto.String("requests_total"), Type: dto.MetricType_COUNTER.Enum()}
+
+		for _, user := range users {
+			family.Metric = append(family.Metric, &dto.Metric{
+				Label: []*dto.LabelPair{
+					{Name: proto.String(prometheusScopeNameLabel), Value: proto.String("a")},
+					{Name: proto.String("user"), Value: proto.String(user)},
+				},
+				Counter: &dto.Counter{Value: proto.Float64(1)},
+			})
+		}
+
+		return []*dto.MetricFamily{family}, nil
+	}), MetricSeriesLimit{Default: 3})
+
+	tests := []struct {
+		name  string
+		users []string
+		want  []string
+	}{
+		{name: "admit until limit - 1", users: []string{"a", "b", "c"}, want: []string{"_overflow:1", "a:1", "b:1"}},
+		{name: "missing attribute set is expired", users: []string{"d", "c"}, want: []string{"c:1", "d:1"}},
+		{name: "admitted attribute set is kept first", users: []string{"e", "f", "d", "c"}, want: []string{"_overflow:2", "c:1", "d:1"}},
+	}
+
+	for _, tt := range tests {
+		users = tt.users
+
+		families, err := gatherer.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+
+		for _, m := range families[0].Metric {
+			user := "_overflow"
+
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "user" {
+					user = label.GetValue()
+				}
+			}
+
+			got = append(got, user+":"+strconv.FormatFloat(m.GetCounter().GetValue(), 'f', -1, 64))
+		}
+
+		sort.Strings(got)
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
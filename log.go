@@ -2,6 +2,10 @@ package otel
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
@@ -13,6 +17,31 @@ import (
 type LogExporterOption struct {
 	GrpcOpts []otlploggrpc.Option
 	HttpOpts []otlploghttp.Option
+	// SeverityFilter drop record below the minimum severity before the batch processor, see NewLogSeverityFilterProcessor
+	SeverityFilter LogSeverityFilterOption
+	// Processor option for the processor that export the record, see NewLogProcessor
+	Processor LogProcessorOption
+	// SyslogOpt option for syslog exporter
+	SyslogOpt SyslogExporterOption
+	// JournaldOpt option for journald exporter
+	JournaldOpt JournaldExporterOption
+	// Dedup rate limit identical record and drop record of not sampled trace before the batch processor, see NewLogDedupProcessor
+	Dedup LogDedupOption
+}
+
+// LogProcessorOption option for log processor, zero value is resolved from OTEL_BLRP_* env
+type LogProcessorOption struct {
+	// Simple export every record synchronously on emit instead of batching, for cli and test.
+	// the batch option and env is ignored
+	Simple bool
+	// ScheduleDelay maximum delay between two export (default: 1s)
+	ScheduleDelay time.Duration
+	// ExportTimeout maximum duration of an export (default: 30s)
+	ExportTimeout time.Duration
+	// MaxQueueSize maximum record kept in the queue, record is dropped when the queue is full (default: 2048)
+	MaxQueueSize int
+	// MaxExportBatchSize maximum record of an export, must not be greater than MaxQueueSize (default: 512)
+	MaxExportBatchSize int
 }
 
 // NewLogExporter new log exporter with defined type
@@ -40,6 +69,17 @@ type LogExporterOption struct {
 // The configuration can be overridden by WithTLSCredentials, WithGRPCConn option.
 //
 // stdout just will print out the log
+//
+// syslog send RFC 5424 syslog message to unix socket, udp or tcp
+// OTEL_EXPORTER_SYSLOG_ENDPOINT = (default: "unix:///dev/log") unix:///path/to/socket, udp://host:port or tcp://host:port
+// OTEL_EXPORTER_SYSLOG_FACILITY = (default: "user") user, daemon or local0 to local7
+// OTEL_EXPORTER_SYSLOG_APP_NAME = (default: service.name resource attribute)
+// The configuration can be overridden by opt.SyslogOpt
+//
+// journald send journal entry with systemd journald native protocol
+// OTEL_EXPORTER_JOURNALD_ENDPOINT = (default: "unix:///run/systemd/journal/socket")
+// OTEL_EXPORTER_JOURNALD_IDENTIFIER = (default: service.name resource attribute) SYSLOG_IDENTIFIER field
+// The configuration can be overridden by opt.JournaldOpt
 func NewLogExporter(ctx context.Context, endpointType LogExporterType, opt LogExporterOption) (sdklog.Exporter, error) {
 	switch endpointType {
 	case HttpLogExporter:
@@ -48,42 +88,175 @@ func NewLogExporter(ctx context.Context, endpointType LogExporterType, opt LogEx
 		return otlploggrpc.New(ctx, opt.GrpcOpts...)
 	case StdOutLogExporter:
 		return stdoutlog.New(stdoutlog.WithPrettyPrint())
+	case SyslogLogExporter:
+		exporter, err := NewSyslogExporter(opt.SyslogOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		return exporter, nil
+	case JournaldLogExporter:
+		exporter, err := NewJournaldExporter(opt.JournaldOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		return exporter, nil
 	}
 
 	return nil, ErrInvalidLogExporterType
 }
 
-// NewLogProvider initiate provider for log
+// NewLogProcessor new batch processor for the exporter or simple processor when processorOpt.Simple is set
+// OTEL_BLRP_SCHEDULE_DELAY = (default: "1000") maximum delay in milliseconds between two export
+// OTEL_BLRP_EXPORT_TIMEOUT = (default: "30000") maximum time in milliseconds of an export
+// OTEL_BLRP_MAX_QUEUE_SIZE = (default: "2048") maximum record kept in the queue
+// OTEL_BLRP_MAX_EXPORT_BATCH_SIZE = (default: "512") maximum record of an export, must not be greater than max queue size
+// The configuration can be overridden by processorOpt.
+// the env is read by sdklog.NewBatchProcessor, unlike the batch processor that fallback to the default,
+// invalid env or option returns ErrInvalidLogProcessor
+func NewLogProcessor(exporter sdklog.Exporter, processorOpt LogProcessorOption) (sdklog.Processor, error) {
+	if processorOpt.Simple {
+		return sdklog.NewSimpleProcessor(exporter), nil
+	}
+
+	err := validateLogProcessorEnv(processorOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero option is resolved from the env by the batch processor
+	var batchOpts []sdklog.BatchProcessorOption
+
+	if processorOpt.ScheduleDelay > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportInterval(processorOpt.ScheduleDelay))
+	}
+
+	if processorOpt.ExportTimeout > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportTimeout(processorOpt.ExportTimeout))
+	}
+
+	if processorOpt.MaxQueueSize > 0 {
+		batchOpts = append(batchOpts, sdklog.WithMaxQueueSize(processorOpt.MaxQueueSize))
+	}
+
+	if processorOpt.MaxExportBatchSize > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportMaxBatchSize(processorOpt.MaxExportBatchSize))
+	}
+
+	return sdklog.NewBatchProcessor(exporter, batchOpts...), nil
+}
+
+// NewLogProvider initiate provider for log with batch processor from OTEL_BLRP_* env, see NewLogProcessor
 func NewLogProvider(res *resource.Resource, exporter sdklog.Exporter, opts ...sdklog.LoggerProviderOption) (*sdklog.LoggerProvider, error) {
+	return NewLogProviderWithProcessor(res, exporter, LogProcessorOption{}, opts...)
+}
+
+// NewLogProviderWithProcessor initiate provider for log, the exporter is wrapped with NewLogProcessor
+//
+//	otel.NewLogProviderWithProcessor(res, exporter, otel.LogProcessorOption{Simple: true})
+func NewLogProviderWithProcessor(res *resource.Resource, exporter sdklog.Exporter, processorOpt LogProcessorOption, opts ...sdklog.LoggerProviderOption) (*sdklog.LoggerProvider, error) {
+	processor, err := NewLogProcessor(exporter, processorOpt)
+	if err != nil {
+		return nil, err
+	}
+
 	return sdklog.NewLoggerProvider(
 		append([]sdklog.LoggerProviderOption{
 			sdklog.WithResource(res),
-			sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+			sdklog.WithProcessor(processor),
 		}, opts...)...,
 	), nil
 }
 
-// InitLogProvider using basic init log without option
+// InitLogProvider using basic init log with optional log provider option
 // this will do init log exporter by exporterType argument
-// pass the exporter to log provider
+// pass the exporter and opts to log provider
 // set new log provider to global
 // and set global context propagation using log context and baggage as propagator
-func InitLogProvider(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+//
+// OTEL_LOGS_MIN_SEVERITY = (default: none) drop record below the severity, supported value trace, debug, info, warn, error and fatal
+// OTEL_LOGS_MIN_SEVERITY_LOGGERS = (default: none) minimum severity per logger name glob, format "payments=debug,*grpc*=error"
+// OTEL_BLRP_SCHEDULE_DELAY, OTEL_BLRP_EXPORT_TIMEOUT, OTEL_BLRP_MAX_QUEUE_SIZE, OTEL_BLRP_MAX_EXPORT_BATCH_SIZE see NewLogProcessor
+// OTEL_LOGS_DEDUP_WINDOW = (default: none) time window in milliseconds of identical record rate limit, see NewLogDedupProcessor
+// OTEL_LOGS_DEDUP_LIMIT = (default: none) maximum identical record per window
+// OTEL_LOGS_SAMPLED_TRACES_ONLY = (default: "false") drop record of not sampled trace
+func InitLogProvider(ctx context.Context, res *resource.Resource, opts ...sdklog.LoggerProviderOption) (*sdklog.LoggerProvider, error) {
+	return initLogProvider(ctx, res, LogExporterOption{}, opts...)
+}
+
+// initLogProvider init log provider with the exporter option, the severity filter and dedup from env is applied
+func initLogProvider(ctx context.Context, res *resource.Resource, exporterOpt LogExporterOption, opts ...sdklog.LoggerProviderOption) (*sdklog.LoggerProvider, error) {
 	exporterType := getLogExporterTypeFromEnv()
 
 	if exporterType == "" {
 		return nil, nil
 	}
 
-	exporter, err := NewLogExporter(ctx, exporterType, LogExporterOption{})
+	severityFilter, err := getLogSeverityFilterFromEnv(exporterOpt.SeverityFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	dedup, err := getLogDedupOptFromEnv(exporterOpt.Dedup)
 	if err != nil {
 		return nil, err
 	}
 
-	logProvider, err := NewLogProvider(res, exporter)
+	exporterOpt.SyslogOpt = getSyslogOptFromEnv(exporterOpt.SyslogOpt)
+	exporterOpt.JournaldOpt = getJournaldOptFromEnv(exporterOpt.JournaldOpt)
+
+	exporter, err := NewLogExporter(ctx, exporterType, exporterOpt)
 	if err != nil {
 		return nil, err
 	}
 
-	return logProvider, nil
+	processor, err := NewLogProcessor(exporter, exporterOpt.Processor)
+	if err != nil {
+		return nil, errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	if dedup.enabled() {
+		dedupProcessor, err := NewLogDedupProcessor(processor, dedup)
+		if err != nil {
+			return nil, errors.Join(err, processor.Shutdown(ctx))
+		}
+
+		processor = dedupProcessor
+	}
+
+	if severityFilter.enabled() {
+		filterProcessor, err := NewLogSeverityFilterProcessor(processor, severityFilter)
+		if err != nil {
+			return nil, errors.Join(err, processor.Shutdown(ctx))
+		}
+
+		processor = filterProcessor
+	}
+
+	return sdklog.NewLoggerProvider(
+		append([]sdklog.LoggerProviderOption{
+			sdklog.WithResource(res),
+			sdklog.WithProcessor(processor),
+		}, opts...)...,
+	), nil
+}
+
+// validate returns error when batch option is not positive or max export batch size is over max queue size
+func (o LogProcessorOption) validate() error {
+	if o.Simple {
+		return nil
+	}
+
+	if o.ScheduleDelay <= 0 || o.ExportTimeout <= 0 || o.MaxQueueSize <= 0 || o.MaxExportBatchSize <= 0 {
+		return fmt.Errorf("%w: schedule delay %s, export timeout %s, max queue size %d and max export batch size %d must be positive",
+			ErrInvalidLogProcessor, o.ScheduleDelay, o.ExportTimeout, o.MaxQueueSize, o.MaxExportBatchSize)
+	}
+
+	if o.MaxExportBatchSize > o.MaxQueueSize {
+		return fmt.Errorf("%w: max export batch size %d is greater than max queue size %d",
+			ErrInvalidLogProcessor, o.MaxExportBatchSize, o.MaxQueueSize)
+	}
+
+	return nil
 }
@@ -12,7 +12,84 @@ const (
 	HttpLogExporter LogExporterType = "http"
 	// StdOutLogExporter exporter stdout type
 	StdOutLogExporter LogExporterType = "stdout"
+	// SyslogLogExporter exporter RFC 5424 syslog type
+	SyslogLogExporter LogExporterType = "syslog"
+	// JournaldLogExporter exporter systemd journald native protocol type
+	JournaldLogExporter LogExporterType = "journald"
 )
 
-// ErrInvalidLogExporterType invalid log exporter type error
-var ErrInvalidLogExporterType = errors.New("invalid log exporter type")
+var (
+	// ErrInvalidLogExporterType invalid log exporter type error
+	ErrInvalidLogExporterType = errors.New("invalid log exporter type")
+	// ErrInvalidLogProcessor batch processor option is not positive or max export batch size over max queue size error
+	ErrInvalidLogProcessor = errors.New("invalid log batch processor option")
+	// ErrInvalidLogDedup negative log dedup window, limit or max keys error
+	ErrInvalidLogDedup = errors.New("invalid log dedup option")
+	// ErrInvalidSyslogEndpoint invalid syslog endpoint error, supported scheme unix, udp and tcp
+	ErrInvalidSyslogEndpoint = errors.New("invalid syslog endpoint")
+	// ErrInvalidSyslogFacility unknown syslog facility error
+	ErrInvalidSyslogFacility = errors.New("invalid syslog facility")
+	// ErrInvalidJournaldEndpoint invalid journald endpoint error, supported scheme unix
+	ErrInvalidJournaldEndpoint = errors.New("invalid journald endpoint")
+)
+
+// LogSeverity minimum severity name for log severity filter
+type LogSeverity string
+
+const (
+	// TraceLogSeverity trace and above
+	TraceLogSeverity LogSeverity = "trace"
+	// DebugLogSeverity debug and above
+	DebugLogSeverity LogSeverity = "debug"
+	// InfoLogSeverity info and above
+	InfoLogSeverity LogSeverity = "info"
+	// WarnLogSeverity warn and above
+	WarnLogSeverity LogSeverity = "warn"
+	// ErrorLogSeverity error and above
+	ErrorLogSeverity LogSeverity = "error"
+	// FatalLogSeverity fatal only
+	FatalLogSeverity LogSeverity = "fatal"
+)
+
+// ErrInvalidLogSeverity invalid log severity error
+var ErrInvalidLogSeverity = errors.New("invalid log severity")
+
+// SyslogFacility facility of syslog message
+type SyslogFacility string
+
+const (
+	// UserSyslogFacility user-level message
+	UserSyslogFacility SyslogFacility = "user"
+	// DaemonSyslogFacility system daemon message
+	DaemonSyslogFacility SyslogFacility = "daemon"
+	// Local0SyslogFacility local use 0
+	Local0SyslogFacility SyslogFacility = "local0"
+	// Local1SyslogFacility local use 1
+	Local1SyslogFacility SyslogFacility = "local1"
+	// Local2SyslogFacility local use 2
+	Local2SyslogFacility SyslogFacility = "local2"
+	// Local3SyslogFacility local use 3
+	Local3SyslogFacility SyslogFacility = "local3"
+	// Local4SyslogFacility local use 4
+	Local4SyslogFacility SyslogFacility = "local4"
+	// Local5SyslogFacility local use 5
+	Local5SyslogFacility SyslogFacility = "local5"
+	// Local6SyslogFacility local use 6
+	Local6SyslogFacility SyslogFacility = "local6"
+	// Local7SyslogFacility local use 7
+	Local7SyslogFacility SyslogFacility = "local7"
+)
+
+// syslogFacilities facility code, RFC 5424 section 6.2.1
+var syslogFacilities = map[SyslogFacility]int{
+	UserSyslogFacility:   1,
+	DaemonSyslogFacility: 3,
+	Local0SyslogFacility: 16,
+	Local1SyslogFacility: 17,
+	Local2SyslogFacility: 18,
+	Local3SyslogFacility: 19,
+	Local4SyslogFacility: 20,
+	Local5SyslogFacility: 21,
+	Local6SyslogFacility: 22,
+	Local7SyslogFacility: 23,
+}
@@ -0,0 +1,164 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+var errTestExport = errors.New("test export failed")
+
+// memoryLogExporter log exporter that keep the exported record in memory
+type memoryLogExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+	err     error
+}
+
+func (e *memoryLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return e.err
+	}
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *memoryLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *memoryLogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *memoryLogExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+// newMemoryLogProvider returns log provider with simple processor to the exporter
+func newMemoryLogProvider(exporter *memoryLogExporter, opts ...sdklog.LoggerProviderOption) *sdklog.LoggerProvider {
+	return sdklog.NewLoggerProvider(append([]sdklog.LoggerProviderOption{
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+	}, opts...)...)
+}
+
+// testLogRecordAttributes returns the record attributes as key and emitted value
+func testLogRecordAttributes(record sdklog.Record) map[string]string {
+	attrs := make(map[string]string, record.AttributesLen())
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+
+	return attrs
+}
+
+func TestNewLogProcessorValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		opt     LogProcessorOption
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "valid env", env: map[string]string{logScheduleDelayEnv: "500", logMaxQueueSizeEnv: "100", logMaxExportBatchSizeEnv: "100"}},
+		{name: "not a number", env: map[string]string{logExportTimeoutEnv: "30s"}, wantErr: true},
+		{name: "zero env", env: map[string]string{logScheduleDelayEnv: "0"}, wantErr: true},
+		{name: "negative env", env: map[string]string{logMaxQueueSizeEnv: "-1"}, wantErr: true},
+		{name: "batch over queue env", env: map[string]string{logMaxQueueSizeEnv: "10", logMaxExportBatchSizeEnv: "20"}, wantErr: true},
+		{name: "batch over default queue", opt: LogProcessorOption{MaxExportBatchSize: 4096}, wantErr: true},
+		{name: "negative option", opt: LogProcessorOption{ExportTimeout: -time.Second}, wantErr: true},
+		{name: "option override invalid env", env: map[string]string{logScheduleDelayEnv: "abc"}, opt: LogProcessorOption{ScheduleDelay: time.Second}},
+		{name: "option batch over env queue", env: map[string]string{logMaxQueueSizeEnv: "10"}, opt: LogProcessorOption{MaxExportBatchSize: 20}, wantErr: true},
+		{name: "simple ignore env", env: map[string]string{logScheduleDelayEnv: "abc"}, opt: LogProcessorOption{Simple: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			processor, err := NewLogProcessor(&memoryLogExporter{}, tt.opt)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLogProcessor) {
+					t.Errorf("got error %v, want %v", err, ErrInvalidLogProcessor)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_ = processor.Shutdown(context.Background())
+		})
+	}
+}
+
+func TestNewLogProcessorEnvPrecedence(t *testing.T) {
+	t.Setenv(logScheduleDelayEnv, "10")
+
+	emit := func(t *testing.T, opt LogProcessorOption) (*memoryLogExporter, *sdklog.LoggerProvider) {
+		exporter := &memoryLogExporter{}
+
+		provider, err := NewLogProviderWithProcessor(nil, exporter, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var record log.Record
+		record.SetBody(log.StringValue("hello"))
+		provider.Logger("test").Emit(context.Background(), record)
+
+		return exporter, provider
+	}
+
+	t.Run("zero option use env", func(t *testing.T) {
+		exporter, provider := emit(t, LogProcessorOption{})
+		defer provider.Shutdown(context.Background())
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(exporter.Records()) == 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(exporter.Records()) != 1 {
+			t.Error("record is not exported after OTEL_BLRP_SCHEDULE_DELAY")
+		}
+	})
+
+	t.Run("option override env", func(t *testing.T) {
+		exporter, provider := emit(t, LogProcessorOption{ScheduleDelay: time.Hour})
+
+		time.Sleep(300 * time.Millisecond)
+
+		if len(exporter.Records()) != 0 {
+			t.Error("record is exported before the option schedule delay")
+		}
+
+		if err := provider.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(exporter.Records()) != 1 {
+			t.Error("record is not exported on shutdown")
+		}
+	})
+}
@@ -0,0 +1,255 @@
+// Package logbridge convert structured field of third party logger to open telemetry log value,
+// used by otelzap, otelzerolog and otellogrus bridge module and the log helper of otel package
+package logbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// nilString string of nil pointer error and fmt.Stringer
+const nilString = "<nil>"
+
+// Logger returns logger from the provider, global log provider is used when nil
+func Logger(provider log.LoggerProvider, name string) log.Logger {
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+
+	return provider.Logger(name)
+}
+
+// ForceFlush flush the provider when it implement ForceFlush, e.g. sdklog.LoggerProvider,
+// global log provider is used when nil. bridge call it before fatal and panic log terminate the process
+func ForceFlush(ctx context.Context, provider log.LoggerProvider) error {
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+
+	if flusher, ok := provider.(interface {
+		ForceFlush(ctx context.Context) error
+	}); ok {
+		return flusher.ForceFlush(ctx)
+	}
+
+	return nil
+}
+
+// Enabled returns whether the logger emit record with the severity
+func Enabled(ctx context.Context, logger log.Logger, severity log.Severity) bool {
+	var param log.EnabledParameters
+	param.SetSeverity(severity)
+
+	return logger.Enabled(ctx, param)
+}
+
+// Value convert go value to log value, unsupported value is formatted with fmt.
+// typed nil pointer of error and fmt.Stringer that panic is converted to "<nil>"
+func Value(v any) log.Value {
+	switch v := v.(type) {
+	case nil:
+		return log.Value{}
+	case log.Value:
+		return v
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case uint64:
+		return uintValue(v)
+	case uintptr:
+		return uintValue(uint64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return log.Int64Value(i)
+		}
+
+		if f, err := v.Float64(); err == nil {
+			return log.Float64Value(f)
+		}
+
+		return log.StringValue(v.String())
+	case []byte:
+		return log.BytesValue(v)
+	case time.Time:
+		return log.StringValue(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return log.StringValue(v.String())
+	case error:
+		return log.StringValue(safeString(v, v.Error))
+	case fmt.Stringer:
+		return log.StringValue(safeString(v, v.String))
+	case []any:
+		values := make([]log.Value, 0, len(v))
+		for _, item := range v {
+			values = append(values, Value(item))
+		}
+
+		return log.SliceValue(values...)
+	case map[string]any:
+		return log.MapValue(KeyValues(v)...)
+	}
+
+	return reflectValue(reflect.ValueOf(v))
+}
+
+// KeyValues convert map to log key value sorted by key
+func KeyValues(fields map[string]any) []log.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	kvs := make([]log.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, log.KeyValue{Key: key, Value: Value(fields[key])})
+	}
+
+	return kvs
+}
+
+// CodeAttributes returns code.filepath, code.lineno and code.function attribute of the caller
+func CodeAttributes(file string, line int, function string) []log.KeyValue {
+	if file == "" {
+		return nil
+	}
+
+	kvs := []log.KeyValue{
+		log.String(string(semconv.CodeFilepathKey), file),
+		log.Int(string(semconv.CodeLineNumberKey), line),
+	}
+
+	if function != "" {
+		kvs = append(kvs, log.String(string(semconv.CodeFunctionKey), function))
+	}
+
+	return kvs
+}
+
+// ContextWithSpanContext returns context with remote span context from hex trace id and span id,
+// the context is returned as is when the id is invalid
+func ContextWithSpanContext(ctx context.Context, traceID, spanID string, sampled bool) context.Context {
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return ctx
+	}
+
+	sid, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return ctx
+	}
+
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: flags,
+	}))
+}
+
+// safeString returns the string of error or fmt.Stringer, "<nil>" when the value is nil pointer
+// and the method panic, e.g. value receiver method or method that doesn't guard nil receiver
+func safeString(v any, str func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			if value := reflect.ValueOf(v); value.Kind() == reflect.Pointer && value.IsNil() {
+				s = nilString
+				return
+			}
+
+			panic(r)
+		}
+	}()
+
+	return str()
+}
+
+// uintValue convert uint to int64, value over max int64 is sent as string
+func uintValue(v uint64) log.Value {
+	if v > math.MaxInt64 {
+		return log.StringValue(strconv.FormatUint(v, 10))
+	}
+
+	return log.Int64Value(int64(v))
+}
+
+// reflectValue convert slice, array, map and pointer with reflection
+func reflectValue(v reflect.Value) log.Value {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return log.Value{}
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return log.Value{}
+		}
+
+		return Value(v.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		values := make([]log.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, Value(v.Index(i).Interface()))
+		}
+
+		return log.SliceValue(values...)
+	case reflect.Map:
+		fields := make(map[string]any, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			fields[fmt.Sprint(iter.Key().Interface())] = iter.Value().Interface()
+		}
+
+		return log.MapValue(KeyValues(fields)...)
+	case reflect.String:
+		return log.StringValue(v.String())
+	case reflect.Bool:
+		return log.BoolValue(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return log.Int64Value(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintValue(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return log.Float64Value(v.Float())
+	}
+
+	return log.StringValue(fmt.Sprintf("%+v", v.Interface()))
+}
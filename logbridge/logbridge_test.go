@@ -0,0 +1,193 @@
+package logbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testError error with pointer receiver that doesn't guard nil receiver
+type testError struct {
+	msg string
+}
+
+func (e *testError) Error() string {
+	return e.msg
+}
+
+// testNilError error with pointer receiver that guard nil receiver
+type testNilError struct{}
+
+func (e *testNilError) Error() string {
+	if e == nil {
+		return "nil test error"
+	}
+
+	return "test error"
+}
+
+// testStringer stringer with value receiver
+type testStringer struct {
+	name string
+}
+
+func (s testStringer) String() string {
+	return "stringer " + s.name
+}
+
+// flushTestProvider log provider that count ForceFlush call
+type flushTestProvider struct {
+	log.LoggerProvider
+
+	flushed int
+	err     error
+}
+
+func (p *flushTestProvider) ForceFlush(context.Context) error {
+	p.flushed++
+	return p.err
+}
+
+func TestValue(t *testing.T) {
+	var (
+		nilError    *testError
+		nilSafe     *testNilError
+		nilStringer *testStringer
+		nilInt      *int
+		number      = 42
+		timestamp   = time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	)
+
+	tests := []struct {
+		name  string
+		value any
+		want  log.Value
+	}{
+		{name: "nil", value: nil, want: log.Value{}},
+		{name: "string", value: "a", want: log.StringValue("a")},
+		{name: "bool", value: true, want: log.BoolValue(true)},
+		{name: "int", value: 1, want: log.IntValue(1)},
+		{name: "int8", value: int8(-1), want: log.Int64Value(-1)},
+		{name: "uint32", value: uint32(1), want: log.Int64Value(1)},
+		{name: "uint64 over max int64", value: uint64(math.MaxUint64), want: log.StringValue("18446744073709551615")},
+		{name: "float32", value: float32(1.5), want: log.Float64Value(1.5)},
+		{name: "json int", value: json.Number("7"), want: log.Int64Value(7)},
+		{name: "json float", value: json.Number("7.5"), want: log.Float64Value(7.5)},
+		{name: "bytes", value: []byte("ab"), want: log.BytesValue([]byte("ab"))},
+		{name: "time", value: timestamp, want: log.StringValue("2024-01-02T03:04:05.000000006Z")},
+		{name: "duration", value: time.Second, want: log.StringValue("1s")},
+		{name: "error", value: errors.New("failed"), want: log.StringValue("failed")},
+		{name: "nil pointer error", value: nilError, want: log.StringValue("<nil>")},
+		{name: "nil pointer error that guard nil", value: nilSafe, want: log.StringValue("nil test error")},
+		{name: "stringer", value: testStringer{name: "a"}, want: log.StringValue("stringer a")},
+		{name: "nil pointer stringer with value receiver", value: nilStringer, want: log.StringValue("<nil>")},
+		{name: "nil pointer", value: nilInt, want: log.Value{}},
+		{name: "pointer", value: &number, want: log.IntValue(42)},
+		{name: "any slice", value: []any{"a", 1}, want: log.SliceValue(log.StringValue("a"), log.IntValue(1))},
+		{name: "typed slice", value: []string{"a", "b"}, want: log.SliceValue(log.StringValue("a"), log.StringValue("b"))},
+		{name: "map", value: map[string]any{"b": 2, "a": "x"}, want: log.MapValue(log.String("a", "x"), log.Int("b", 2))},
+		{name: "typed map", value: map[int]bool{1: true}, want: log.MapValue(log.Bool("1", true))},
+		{name: "struct", value: struct{ A int }{A: 1}, want: log.StringValue("{A:1}")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Value(tt.value); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueNonNilPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("panic of non nil value is recovered")
+		}
+	}()
+
+	Value(panicTestStringer{})
+}
+
+// panicTestStringer stringer that always panic
+type panicTestStringer struct{}
+
+func (panicTestStringer) String() string {
+	panic("stringer failed")
+}
+
+func TestKeyValues(t *testing.T) {
+	got := KeyValues(map[string]any{"b": 1, "a": "x"})
+	want := []log.KeyValue{log.String("a", "x"), log.Int("b", 1)}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+}
+
+func TestCodeAttributes(t *testing.T) {
+	if got := CodeAttributes("", 10, "main"); got != nil {
+		t.Errorf("got %v without file, want nil", got)
+	}
+
+	got := CodeAttributes("main.go", 10, "main.run")
+	want := []log.KeyValue{log.String("code.filepath", "main.go"), log.Int("code.lineno", 10), log.String("code.function", "main.run")}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+}
+
+func TestContextWithSpanContext(t *testing.T) {
+	ctx := ContextWithSpanContext(context.Background(), "0102030405060708090a0b0c0d0e0f10", "0102030405060708", true)
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.TraceID().String() != "0102030405060708090a0b0c0d0e0f10" || spanContext.SpanID().String() != "0102030405060708" {
+		t.Errorf("got span context %s %s", spanContext.TraceID(), spanContext.SpanID())
+	}
+
+	if !spanContext.IsSampled() || !spanContext.IsRemote() {
+		t.Errorf("got sampled %v remote %v, want sampled remote span context", spanContext.IsSampled(), spanContext.IsRemote())
+	}
+
+	if ctx := ContextWithSpanContext(context.Background(), "invalid", "0102030405060708", true); trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("got valid span context from invalid trace id")
+	}
+}
+
+func TestForceFlush(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	provider := &flushTestProvider{err: errFlush}
+
+	if err := ForceFlush(context.Background(), provider); !errors.Is(err, errFlush) || provider.flushed != 1 {
+		t.Errorf("got %v and %d flush, want %v and 1 flush", err, provider.flushed, errFlush)
+	}
+
+	if err := ForceFlush(context.Background(), sdklog.NewLoggerProvider()); err != nil {
+		t.Errorf("got %v from sdk log provider, want nil", err)
+	}
+
+	// provider without ForceFlush is skipped
+	if err := ForceFlush(context.Background(), nil); err != nil {
+		t.Errorf("got %v from global log provider, want nil", err)
+	}
+}
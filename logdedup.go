@@ -0,0 +1,326 @@
+package otel
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// default log dedup setting
+const (
+	logDedupWindowDefault     = 10 * time.Second
+	logDedupLimitDefault      = 1
+	logDedupMaxKeysDefault    = 10000
+	logDedupSuppressedKey     = "log.dedup.suppressed_count"
+	logDedupWindowKey         = "log.dedup.window"
+	logDedupKeySeparator      = "\x00"
+	logDedupNumberPlaceholder = '#'
+)
+
+// LogDedupOption option for log dedup processor
+type LogDedupOption struct {
+	// Window time window of the rate limit, dedup is disabled when both Window and Limit is zero (default: 10s)
+	Window time.Duration
+	// Limit maximum identical record processed per window, the rest is suppressed (default: 1)
+	Limit int
+	// MaxKeys maximum identical record group tracked per window, new group is not rate limited when full (default: 10000)
+	MaxKeys int
+	// SampledTracesOnly drop record of not sampled trace, record without trace is kept
+	SampledTracesOnly bool
+}
+
+// logDedupProcessor processor wrapper that rate limit identical record and drop record of not sampled trace
+type logDedupProcessor struct {
+	sdklog.Processor
+
+	window            time.Duration
+	limit             int
+	maxKeys           int
+	sampledTracesOnly bool
+
+	mu        sync.Mutex
+	entries   map[string]*logDedupEntry
+	lastSweep time.Time
+
+	// done stop the summary ticker on Shutdown
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  sync.WaitGroup
+}
+
+// logDedupEntry identical record count of the current window
+type logDedupEntry struct {
+	start      time.Time
+	count      int
+	suppressed int
+	// summary clone of the first suppressed record, emitted with the suppressed count when the window end
+	summary *sdklog.Record
+}
+
+// enabled returns true when dedup or sampled traces only is set
+func (o LogDedupOption) enabled() bool {
+	return o.dedup() || o.SampledTracesOnly
+}
+
+// dedup returns true when identical record is rate limited
+func (o LogDedupOption) dedup() bool {
+	return o.Window != 0 || o.Limit != 0
+}
+
+// NewLogDedupProcessor wrap the processor to rate limit identical record, the record with same logger,
+// severity and body is identical, digit in the body is ignored so formatted id and count is grouped.
+// a summary record with log.dedup.suppressed_count attribute is emitted for the suppressed record
+// by a ticker on every window, when the next identical record come after the window, on ForceFlush and on Shutdown
+//
+//	processor, err := otel.NewLogDedupProcessor(sdklog.NewBatchProcessor(exporter), otel.LogDedupOption{
+//		Window:            time.Minute,
+//		Limit:             10,
+//		SampledTracesOnly: true,
+//	})
+func NewLogDedupProcessor(processor sdklog.Processor, opt LogDedupOption) (sdklog.Processor, error) {
+	if opt.Window < 0 || opt.Limit < 0 || opt.MaxKeys < 0 {
+		return nil, fmt.Errorf("%w: window %s, limit %d and max keys %d must not be negative",
+			ErrInvalidLogDedup, opt.Window, opt.Limit, opt.MaxKeys)
+	}
+
+	dedup := &logDedupProcessor{
+		Processor:         processor,
+		sampledTracesOnly: opt.SampledTracesOnly,
+		entries:           make(map[string]*logDedupEntry),
+		done:              make(chan struct{}),
+	}
+
+	if opt.dedup() {
+		dedup.window = cmp.Or(opt.Window, logDedupWindowDefault)
+		dedup.limit = cmp.Or(opt.Limit, logDedupLimitDefault)
+		dedup.maxKeys = cmp.Or(opt.MaxKeys, logDedupMaxKeysDefault)
+
+		dedup.stopped.Add(1)
+		go dedup.run()
+	}
+
+	return dedup, nil
+}
+
+// run emit summary of the expired entry on every window until Shutdown,
+// so suppressed record is reported even when no other record is emitted
+func (p *logDedupProcessor) run() {
+	defer p.stopped.Done()
+
+	ticker := time.NewTicker(p.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			summaries := p.expire(now)
+			p.mu.Unlock()
+
+			if err := p.emit(context.Background(), summaries); err != nil {
+				otel.Handle(err)
+			}
+		}
+	}
+}
+
+// OnEmit pass the record to the processor when it is not over the limit of the window
+func (p *logDedupProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if p.sampledTracesOnly && record.TraceID().IsValid() && !record.TraceFlags().IsSampled() {
+		return nil
+	}
+
+	if p.window == 0 {
+		return p.Processor.OnEmit(ctx, record)
+	}
+
+	var (
+		now = time.Now()
+		key = logDedupKey(record)
+	)
+
+	p.mu.Lock()
+
+	summaries := p.sweep(now)
+
+	entry, ok := p.entries[key]
+	if !ok {
+		if len(p.entries) >= p.maxKeys {
+			p.mu.Unlock()
+			return errors.Join(p.emit(ctx, summaries), p.Processor.OnEmit(ctx, record))
+		}
+
+		entry = &logDedupEntry{start: now}
+		p.entries[key] = entry
+	}
+
+	if now.Sub(entry.start) >= p.window {
+		if summary := entry.summaryRecord(now, p.window); summary != nil {
+			summaries = append(summaries, summary)
+		}
+
+		*entry = logDedupEntry{start: now}
+	}
+
+	entry.count++
+
+	suppress := entry.count > p.limit
+	if suppress {
+		if entry.summary == nil {
+			summary := record.Clone()
+			entry.summary = &summary
+		}
+
+		entry.suppressed++
+	}
+
+	p.mu.Unlock()
+
+	err := p.emit(ctx, summaries)
+	if suppress {
+		return err
+	}
+
+	return errors.Join(err, p.Processor.OnEmit(ctx, record))
+}
+
+// Enabled delegate to the processor, record can't be deduplicated before it is emitted
+func (p *logDedupProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	return processorEnabled(ctx, p.Processor, param)
+}
+
+// ForceFlush emit summary of every suppressed record and flush the processor
+func (p *logDedupProcessor) ForceFlush(ctx context.Context) error {
+	return errors.Join(p.emit(ctx, p.flush()), p.Processor.ForceFlush(ctx))
+}
+
+// Shutdown stop the summary ticker, emit summary of every suppressed record and shutdown the processor
+func (p *logDedupProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.done) })
+	p.stopped.Wait()
+
+	return errors.Join(p.emit(ctx, p.flush()), p.Processor.Shutdown(ctx))
+}
+
+// sweep remove expired entry once per window and returns the summary record, the lock must be held
+func (p *logDedupProcessor) sweep(now time.Time) []*sdklog.Record {
+	if now.Sub(p.lastSweep) < p.window {
+		return nil
+	}
+
+	return p.expire(now)
+}
+
+// expire remove expired entry and returns the summary record, the lock must be held
+func (p *logDedupProcessor) expire(now time.Time) []*sdklog.Record {
+	p.lastSweep = now
+
+	var summaries []*sdklog.Record
+
+	for key, entry := range p.entries {
+		if now.Sub(entry.start) < p.window {
+			continue
+		}
+
+		if summary := entry.summaryRecord(now, p.window); summary != nil {
+			summaries = append(summaries, summary)
+		}
+
+		delete(p.entries, key)
+	}
+
+	return summaries
+}
+
+// flush returns summary record of every entry and reset the suppressed count
+func (p *logDedupProcessor) flush() []*sdklog.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		now       = time.Now()
+		summaries []*sdklog.Record
+	)
+
+	for _, entry := range p.entries {
+		if summary := entry.summaryRecord(now, p.window); summary != nil {
+			summaries = append(summaries, summary)
+		}
+
+		entry.suppressed = 0
+		entry.summary = nil
+	}
+
+	return summaries
+}
+
+// emit pass the summary record to the processor
+func (p *logDedupProcessor) emit(ctx context.Context, summaries []*sdklog.Record) error {
+	var errs []error
+
+	for _, summary := range summaries {
+		errs = append(errs, p.Processor.OnEmit(ctx, summary))
+	}
+
+	return errors.Join(errs...)
+}
+
+// summaryRecord returns the first suppressed record with the suppressed count, nil when nothing is suppressed
+func (e *logDedupEntry) summaryRecord(now time.Time, window time.Duration) *sdklog.Record {
+	if e.suppressed == 0 || e.summary == nil {
+		return nil
+	}
+
+	summary := e.summary.Clone()
+	summary.SetTimestamp(now)
+	summary.SetObservedTimestamp(now)
+	summary.AddAttributes(
+		log.Int(logDedupSuppressedKey, e.suppressed),
+		log.String(logDedupWindowKey, window.String()),
+	)
+
+	return &summary
+}
+
+// logDedupKey returns "logger severity body" key of the record with digit run replaced by placeholder
+func logDedupKey(record *sdklog.Record) string {
+	var (
+		b     strings.Builder
+		digit bool
+	)
+
+	b.WriteString(record.InstrumentationScope().Name)
+	b.WriteString(logDedupKeySeparator)
+	b.WriteString(strconv.Itoa(int(record.Severity())))
+	b.WriteString(logDedupKeySeparator)
+
+	for _, r := range record.Body().String() {
+		if unicode.IsDigit(r) {
+			if !digit {
+				b.WriteRune(logDedupNumberPlaceholder)
+			}
+
+			digit = true
+
+			continue
+		}
+
+		digit = false
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
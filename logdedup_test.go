@@ -0,0 +1,164 @@
+package otel
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestLogDedupLogger returns logger that emit to the dedup processor of memory exporter
+func newTestLogDedupLogger(t *testing.T, opt LogDedupOption) (log.Logger, sdklog.Processor, *memoryLogExporter) {
+	t.Helper()
+
+	exporter := &memoryLogExporter{}
+
+	processor, err := NewLogDedupProcessor(sdklog.NewSimpleProcessor(exporter), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	return provider.Logger("test"), processor, exporter
+}
+
+func emitTestDedupRecord(ctx context.Context, logger log.Logger, body string) {
+	var record log.Record
+
+	record.SetSeverity(log.SeverityWarn)
+	record.SetBody(log.StringValue(body))
+	logger.Emit(ctx, record)
+}
+
+// testDedupSuppressed returns the suppressed count of the summary record, 0 when it is not summary
+func testDedupSuppressed(record sdklog.Record) int64 {
+	var suppressed int64
+
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == logDedupSuppressedKey {
+			suppressed = kv.Value.AsInt64()
+		}
+
+		return true
+	})
+
+	return suppressed
+}
+
+func TestLogDedupProcessorWindow(t *testing.T) {
+	logger, processor, exporter := newTestLogDedupLogger(t, LogDedupOption{Window: time.Hour, Limit: 2})
+
+	// digit is ignored so every record is identical
+	for i := 0; i < 5; i++ {
+		emitTestDedupRecord(context.Background(), logger, "retry "+strconv.Itoa(i)+" failed")
+	}
+
+	emitTestDedupRecord(context.Background(), logger, "other")
+
+	records := exporter.Records()
+	if len(records) != 3 || records[0].Body().AsString() != "retry 0 failed" || records[1].Body().AsString() != "retry 1 failed" {
+		t.Fatalf("got %d records, want 2 identical record under the limit and other record", len(records))
+	}
+
+	if err := processor.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	records = exporter.Records()
+	if len(records) != 4 || testDedupSuppressed(records[3]) != 3 || records[3].Body().AsString() != "retry 2 failed" {
+		t.Fatalf("got %d records, want summary of the first suppressed record with 3 suppressed", len(records))
+	}
+
+	// the count is reset after the summary
+	if err := processor.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exporter.Records()) != 4 {
+		t.Errorf("summary is emitted again after flush")
+	}
+}
+
+func TestLogDedupProcessorTicker(t *testing.T) {
+	logger, _, exporter := newTestLogDedupLogger(t, LogDedupOption{Window: 20 * time.Millisecond, Limit: 1})
+
+	for i := 0; i < 3; i++ {
+		emitTestDedupRecord(context.Background(), logger, "timeout")
+	}
+
+	// summary is emitted by the ticker without other record, flush or shutdown
+	deadline := time.Now().Add(5 * time.Second)
+	for len(exporter.Records()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("summary is not emitted after the window")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if records := exporter.Records(); testDedupSuppressed(records[1]) != 2 {
+		t.Errorf("got suppressed count %d, want 2", testDedupSuppressed(records[1]))
+	}
+}
+
+func TestLogDedupProcessorMaxKeys(t *testing.T) {
+	logger, _, exporter := newTestLogDedupLogger(t, LogDedupOption{Window: time.Hour, Limit: 1, MaxKeys: 2})
+
+	for i := 0; i < 2; i++ {
+		for _, body := range []string{"a", "b", "c"} {
+			emitTestDedupRecord(context.Background(), logger, body)
+		}
+	}
+
+	// a and b is tracked and rate limited, c is over max keys and passed as is
+	var got []string
+	for _, record := range exporter.Records() {
+		got = append(got, record.Body().AsString())
+	}
+
+	if want := "a b c c"; strings.Join(got, " ") != want {
+		t.Errorf("got records %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestLogDedupProcessorSampledTracesOnly(t *testing.T) {
+	logger, _, exporter := newTestLogDedupLogger(t, LogDedupOption{SampledTracesOnly: true})
+
+	spanContext := func(flags trace.TraceFlags) context.Context {
+		return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+		}))
+	}
+
+	emitTestDedupRecord(spanContext(trace.FlagsSampled), logger, "sampled")
+	emitTestDedupRecord(spanContext(0), logger, "not sampled")
+	emitTestDedupRecord(context.Background(), logger, "no trace")
+	emitTestDedupRecord(context.Background(), logger, "no trace")
+
+	// dedup is disabled so identical record without trace is kept
+	var got []string
+	for _, record := range exporter.Records() {
+		got = append(got, record.Body().AsString())
+	}
+
+	if want := "sampled no trace no trace"; strings.Join(got, " ") != want {
+		t.Errorf("got records %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestNewLogDedupProcessorValidation(t *testing.T) {
+	for _, opt := range []LogDedupOption{{Window: -time.Second}, {Limit: -1}, {MaxKeys: -1}} {
+		if _, err := NewLogDedupProcessor(sdklog.NewSimpleProcessor(&memoryLogExporter{}), opt); err == nil {
+			t.Errorf("option %+v got no error", opt)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// LogSeverityFilterOption option for log severity filter processor
+type LogSeverityFilterOption struct {
+	// MinSeverity minimum severity of every logger, empty keep all severity
+	MinSeverity LogSeverity
+	// Loggers minimum severity per logger name, the first matching logger is used
+	Loggers []LoggerSeverity
+}
+
+// LoggerSeverity minimum severity of the logger (instrumentation scope) name
+type LoggerSeverity struct {
+	// Logger logger name, support "*" and "?" glob pattern
+	Logger string
+	// MinSeverity minimum severity of the logger
+	MinSeverity LogSeverity
+}
+
+// logSeverityFilterProcessor processor wrapper that drop record below the minimum severity
+type logSeverityFilterProcessor struct {
+	sdklog.Processor
+
+	minSeverity log.Severity
+	loggers     []loggerSeverity
+	// lowest minimum severity of every logger for Enabled
+	lowest log.Severity
+}
+
+type loggerSeverity struct {
+	pattern     *regexp.Regexp
+	minSeverity log.Severity
+}
+
+// enabled returns true when the filter has minimum severity
+func (o LogSeverityFilterOption) enabled() bool {
+	return o.MinSeverity != "" || len(o.Loggers) > 0
+}
+
+// NewLogSeverityFilterProcessor wrap the processor to drop record below the minimum severity,
+// record without severity is always processed
+//
+//	processor, err := otel.NewLogSeverityFilterProcessor(sdklog.NewBatchProcessor(exporter), otel.LogSeverityFilterOption{
+//		MinSeverity: otel.WarnLogSeverity,
+//		Loggers:     []otel.LoggerSeverity{{Logger: "payments", MinSeverity: otel.DebugLogSeverity}},
+//	})
+func NewLogSeverityFilterProcessor(processor sdklog.Processor, opt LogSeverityFilterOption) (sdklog.Processor, error) {
+	minSeverity, err := opt.MinSeverity.severity()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &logSeverityFilterProcessor{
+		Processor:   processor,
+		minSeverity: minSeverity,
+		lowest:      minSeverity,
+	}
+
+	for _, logger := range opt.Loggers {
+		loggerMinSeverity, err := logger.MinSeverity.severity()
+		if err != nil {
+			return nil, fmt.Errorf("logger %s: %w", logger.Logger, err)
+		}
+
+		filter.loggers = append(filter.loggers, loggerSeverity{
+			pattern:     globRegexp(logger.Logger, ""),
+			minSeverity: loggerMinSeverity,
+		})
+		filter.lowest = min(filter.lowest, loggerMinSeverity)
+	}
+
+	return filter, nil
+}
+
+// OnEmit pass the record to the processor when the severity is not below the logger minimum severity
+func (p *logSeverityFilterProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	severity := record.Severity()
+	if severity != log.SeverityUndefined && severity < p.loggerMinSeverity(record.InstrumentationScope().Name) {
+		return nil
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// Enabled returns false when the severity is below every minimum severity, so log bridge can skip the record
+func (p *logSeverityFilterProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	severity, ok := param.Severity()
+	if ok && severity != log.SeverityUndefined && severity < p.lowest {
+		return false
+	}
+
+	return processorEnabled(ctx, p.Processor, param)
+}
+
+// processorEnabled returns Enabled of the processor when it is implemented, otherwise true
+func processorEnabled(ctx context.Context, processor sdklog.Processor, param log.EnabledParameters) bool {
+	if filter, ok := processor.(interface {
+		Enabled(ctx context.Context, param log.EnabledParameters) bool
+	}); ok {
+		return filter.Enabled(ctx, param)
+	}
+
+	return true
+}
+
+func (p *logSeverityFilterProcessor) loggerMinSeverity(name string) log.Severity {
+	for _, logger := range p.loggers {
+		if logger.pattern.MatchString(name) {
+			return logger.minSeverity
+		}
+	}
+
+	return p.minSeverity
+}
+
+// severity returns the lowest log severity of the level, empty is undefined severity that keep all record
+func (s LogSeverity) severity() (log.Severity, error) {
+	switch s {
+	case "":
+		return log.SeverityUndefined, nil
+	case TraceLogSeverity:
+		return log.SeverityTrace1, nil
+	case DebugLogSeverity:
+		return log.SeverityDebug1, nil
+	case InfoLogSeverity:
+		return log.SeverityInfo1, nil
+	case WarnLogSeverity:
+		return log.SeverityWarn1, nil
+	case ErrorLogSeverity:
+		return log.SeverityError1, nil
+	case FatalLogSeverity:
+		return log.SeverityFatal1, nil
+	}
+
+	return log.SeverityUndefined, fmt.Errorf("%w: %q", ErrInvalidLogSeverity, s)
+}
@@ -0,0 +1,137 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// enabledTestProcessor processor that count Enabled call and returns the enabled value
+type enabledTestProcessor struct {
+	sdklog.Processor
+
+	enabled bool
+	calls   int
+}
+
+func (p *enabledTestProcessor) Enabled(context.Context, log.EnabledParameters) bool {
+	p.calls++
+	return p.enabled
+}
+
+func TestLogSeverityFilterProcessorLoggerOrder(t *testing.T) {
+	exporter := &memoryLogExporter{}
+
+	processor, err := NewLogSeverityFilterProcessor(sdklog.NewSimpleProcessor(exporter), LogSeverityFilterOption{
+		MinSeverity: WarnLogSeverity,
+		Loggers: []LoggerSeverity{
+			{Logger: "payments", MinSeverity: DebugLogSeverity},
+			{Logger: "payments*", MinSeverity: ErrorLogSeverity},
+			{Logger: "*grpc?", MinSeverity: InfoLogSeverity},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+
+	tests := []struct {
+		logger   string
+		severity log.Severity
+		want     bool
+	}{
+		// exact pattern listed first win over the later glob
+		{logger: "payments", severity: log.SeverityDebug, want: true},
+		{logger: "payments-worker", severity: log.SeverityWarn, want: false},
+		{logger: "payments-worker", severity: log.SeverityError, want: true},
+		{logger: "google.golang.org/grpc1", severity: log.SeverityInfo, want: true},
+		{logger: "google.golang.org/grpc", severity: log.SeverityInfo, want: false},
+		{logger: "orders", severity: log.SeverityInfo, want: false},
+		{logger: "orders", severity: log.SeverityWarn, want: true},
+		// record without severity is always processed
+		{logger: "orders", severity: log.SeverityUndefined, want: true},
+	}
+
+	for _, tt := range tests {
+		before := len(exporter.Records())
+
+		var record log.Record
+		record.SetSeverity(tt.severity)
+		record.SetBody(log.StringValue("message"))
+		provider.Logger(tt.logger).Emit(context.Background(), record)
+
+		if got := len(exporter.Records()) > before; got != tt.want {
+			t.Errorf("logger %s severity %s exported = %v, want %v", tt.logger, tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestLogSeverityFilterProcessorEnabled(t *testing.T) {
+	next := &enabledTestProcessor{Processor: sdklog.NewSimpleProcessor(&memoryLogExporter{}), enabled: true}
+
+	processor, err := NewLogSeverityFilterProcessor(next, LogSeverityFilterOption{
+		MinSeverity: WarnLogSeverity,
+		Loggers:     []LoggerSeverity{{Logger: "payments", MinSeverity: InfoLogSeverity}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := processor.(*logSeverityFilterProcessor)
+
+	// below the lowest minimum severity of every logger is rejected without asking the next processor
+	var param log.EnabledParameters
+	param.SetSeverity(log.SeverityDebug)
+
+	if filter.Enabled(context.Background(), param) {
+		t.Error("debug enabled, want disabled")
+	}
+
+	if next.calls != 0 {
+		t.Errorf("next processor Enabled called %d times, want 0", next.calls)
+	}
+
+	// info is enabled for payments logger so the next processor decide
+	param.SetSeverity(log.SeverityInfo)
+
+	if !filter.Enabled(context.Background(), param) {
+		t.Error("info disabled, want enabled")
+	}
+
+	next.enabled = false
+
+	if filter.Enabled(context.Background(), param) {
+		t.Error("info enabled when next processor is disabled, want disabled")
+	}
+
+	// undefined and unset severity is passed to the next processor
+	next.enabled = true
+	next.calls = 0
+
+	param.SetSeverity(log.SeverityUndefined)
+
+	if !filter.Enabled(context.Background(), param) {
+		t.Error("undefined severity disabled, want enabled")
+	}
+
+	if !filter.Enabled(context.Background(), log.EnabledParameters{}) {
+		t.Error("unset severity disabled, want enabled")
+	}
+
+	if next.calls != 2 {
+		t.Errorf("next processor Enabled called %d times, want 2", next.calls)
+	}
+}
+
+func TestNewLogSeverityFilterProcessorInvalid(t *testing.T) {
+	_, err := NewLogSeverityFilterProcessor(sdklog.NewSimpleProcessor(&memoryLogExporter{}), LogSeverityFilterOption{
+		Loggers: []LoggerSeverity{{Logger: "payments", MinSeverity: "verbose"}},
+	})
+	if !errors.Is(err, ErrInvalidLogSeverity) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidLogSeverity)
+	}
+}
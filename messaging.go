@@ -0,0 +1,102 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// messaging operation name
+const (
+	messagingOperationPublish = "publish"
+	messagingOperationProcess = "process"
+)
+
+// Inject starts producer span for publishing the message
+// and inject the span context into the carrier using global text map propagator.
+// the end function must be deferred, see Start
+//
+//	ctx, end := otel.Inject(ctx, otel.Message{System: "kafka", Destination: "orders"}, otel.NewHeaderCarrier(&headers))
+//	defer end(&err)
+func Inject(ctx context.Context, msg Message, carrier propagation.TextMapCarrier) (context.Context, SpanEndFunc) {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, messagingSpanName(messagingOperationPublish, msg),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingAttributes(semconv.MessagingOperationTypePublish, messagingOperationPublish, msg)...),
+	)
+
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	return ctx, newSpanEndFunc(span)
+}
+
+// Extract extract the span context from the carrier using global text map propagator
+// and starts consumer span for processing the message as child of the producer span.
+// the end function must be deferred, see Start
+func Extract(ctx context.Context, msg Message, carrier propagation.TextMapCarrier) (context.Context, SpanEndFunc) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, messagingSpanName(messagingOperationProcess, msg),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttributes(semconv.MessagingOperationTypeDeliver, messagingOperationProcess, msg)...),
+	)
+
+	return ctx, newSpanEndFunc(span)
+}
+
+// ExtractBatch extract the span context from every carrier in the batch
+// and starts consumer span for processing the batch linked to every producer span.
+// the end function must be deferred, see Start
+func ExtractBatch(ctx context.Context, msg Message, carriers ...propagation.TextMapCarrier) (context.Context, SpanEndFunc) {
+	propagator := otel.GetTextMapPropagator()
+	links := make([]trace.Link, 0, len(carriers))
+
+	for _, carrier := range carriers {
+		spanContext := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+		if spanContext.IsValid() {
+			links = append(links, trace.Link{SpanContext: spanContext})
+		}
+	}
+
+	attrs := append(messagingAttributes(semconv.MessagingOperationTypeDeliver, messagingOperationProcess, msg),
+		semconv.MessagingBatchMessageCount(len(carriers)))
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, messagingSpanName(messagingOperationProcess, msg),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(links...),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, newSpanEndFunc(span)
+}
+
+// messagingSpanName span name following messaging semantic convention "{operation} {destination}"
+func messagingSpanName(operation string, msg Message) string {
+	if msg.Destination == "" {
+		return operation
+	}
+
+	return operation + " " + msg.Destination
+}
+
+func messagingAttributes(operationType attribute.KeyValue, operation string, msg Message) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(msg.Attributes)+5)
+	attrs = append(attrs, operationType, semconv.MessagingOperationName(operation))
+
+	if msg.System != "" {
+		attrs = append(attrs, semconv.MessagingSystemKey.String(msg.System))
+	}
+
+	if msg.Destination != "" {
+		attrs = append(attrs, semconv.MessagingDestinationName(msg.Destination))
+	}
+
+	if msg.ID != "" {
+		attrs = append(attrs, semconv.MessagingMessageID(msg.ID))
+	}
+
+	return append(attrs, msg.Attributes...)
+}
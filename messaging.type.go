@@ -0,0 +1,15 @@
+package otel
+
+import "go.opentelemetry.io/otel/attribute"
+
+// Message messaging information used for producer and consumer span attributes
+type Message struct {
+	// System messaging system identifier, e.g. kafka, nats, rabbitmq
+	System string
+	// Destination message destination name, e.g. topic or queue name
+	Destination string
+	// ID message identifier, optional
+	ID string
+	// Attributes additional span attributes
+	Attributes []attribute.KeyValue
+}
@@ -0,0 +1,165 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setTestTracerProvider set global tracer provider and trace context propagator that record the ended span
+// until the test end
+func setTestTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	var (
+		recorder         = tracetest.NewSpanRecorder()
+		provider         = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		beforeProvider   = otel.GetTracerProvider()
+		beforePropagator = otel.GetTextMapPropagator()
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(beforeProvider)
+		otel.SetTextMapPropagator(beforePropagator)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return recorder
+}
+
+// testSpanAttributes returns the span attributes by key
+func testSpanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return attrs
+}
+
+func TestInjectExtract(t *testing.T) {
+	recorder := setTestTracerProvider(t)
+	msg := Message{System: "kafka", Destination: "orders", ID: "1", Attributes: []attribute.KeyValue{attribute.String("key", "value")}}
+
+	var headers []Header
+
+	_, endInject := Inject(context.Background(), msg, NewHeaderCarrier(&headers))
+	endInject(nil)
+
+	_, endExtract := Extract(context.Background(), msg, NewHeaderCarrier(&headers))
+	endExtract(nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	producer, consumer := spans[0], spans[1]
+
+	if producer.Name() != "publish orders" || producer.SpanKind() != trace.SpanKindProducer {
+		t.Errorf("got producer span %q kind %s", producer.Name(), producer.SpanKind())
+	}
+
+	if consumer.Name() != "process orders" || consumer.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("got consumer span %q kind %s", consumer.Name(), consumer.SpanKind())
+	}
+
+	if consumer.Parent().SpanID() != producer.SpanContext().SpanID() || !consumer.Parent().IsRemote() ||
+		consumer.SpanContext().TraceID() != producer.SpanContext().TraceID() {
+		t.Errorf("got consumer parent %+v, want remote producer span %+v", consumer.Parent(), producer.SpanContext())
+	}
+
+	tests := []struct {
+		span          sdktrace.ReadOnlySpan
+		operationType attribute.KeyValue
+		operation     string
+	}{
+		{span: producer, operationType: semconv.MessagingOperationTypePublish, operation: "publish"},
+		{span: consumer, operationType: semconv.MessagingOperationTypeDeliver, operation: "process"},
+	}
+
+	for _, tt := range tests {
+		attrs := testSpanAttributes(tt.span)
+
+		for _, want := range []attribute.KeyValue{
+			tt.operationType,
+			semconv.MessagingOperationName(tt.operation),
+			semconv.MessagingSystemKey.String("kafka"),
+			semconv.MessagingDestinationName("orders"),
+			semconv.MessagingMessageID("1"),
+			attribute.String("key", "value"),
+		} {
+			if got := attrs[want.Key]; got != want.Value {
+				t.Errorf("span %s got %s=%q, want %q", tt.span.Name(), want.Key, got.Emit(), want.Value.Emit())
+			}
+		}
+	}
+}
+
+func TestExtractBatch(t *testing.T) {
+	recorder := setTestTracerProvider(t)
+	msg := Message{System: "nats"}
+
+	carriers := make([]propagation.TextMapCarrier, 0, 3)
+	producers := make([]trace.SpanContext, 0, 2)
+
+	for i := 0; i < 2; i++ {
+		carrier := MultiMapCarrier{}
+		ctx, end := Inject(context.Background(), msg, carrier)
+		end(nil)
+
+		carriers = append(carriers, carrier)
+		producers = append(producers, trace.SpanContextFromContext(ctx))
+	}
+
+	// message without span context is counted but not linked
+	carriers = append(carriers, MultiMapCarrier{})
+
+	_, end := ExtractBatch(context.Background(), msg, carriers...)
+	end(nil)
+
+	spans := recorder.Ended()
+	consumer := spans[len(spans)-1]
+
+	if consumer.Name() != "process" || consumer.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("got consumer span %q kind %s", consumer.Name(), consumer.SpanKind())
+	}
+
+	if consumer.Parent().IsValid() {
+		t.Errorf("got parent %+v, want batch span as root", consumer.Parent())
+	}
+
+	links := consumer.Links()
+	if len(links) != len(producers) {
+		t.Fatalf("got %d links, want %d", len(links), len(producers))
+	}
+
+	for i, link := range links {
+		if link.SpanContext.SpanID() != producers[i].SpanID() || link.SpanContext.TraceID() != producers[i].TraceID() {
+			t.Errorf("link %d got %+v, want producer %+v", i, link.SpanContext, producers[i])
+		}
+	}
+
+	attrs := testSpanAttributes(consumer)
+
+	if got := attrs[semconv.MessagingBatchMessageCountKey]; got.AsInt64() != 3 {
+		t.Errorf("got batch message count %d, want 3", got.AsInt64())
+	}
+
+	if got := attrs[semconv.MessagingSystemKey]; got.AsString() != "nats" {
+		t.Errorf("got messaging system %q, want nats", got.AsString())
+	}
+
+	if _, ok := attrs[semconv.MessagingDestinationNameKey]; ok {
+		t.Error("got destination name for message without destination")
+	}
+}
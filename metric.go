@@ -2,12 +2,18 @@ package otel
 
 import (
 	"context"
+	"errors"
+	"net/http"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -16,6 +22,24 @@ type MetricExporterOption struct {
 	GrpcOpts   []otlpmetricgrpc.Option
 	HttpOpts   []otlpmetrichttp.Option
 	ReaderOpts []sdkmetric.PeriodicReaderOption
+	// PrometheusOpts option for prometheus exporter, e.g. prometheus.WithNamespace, prometheus.WithoutUnits,
+	// prometheus.WithoutCounterSuffixes, prometheus.WithoutScopeInfo and prometheus.WithoutTargetInfo
+	PrometheusOpts []prometheus.Option
+	// PrometheusRegistry registry for prometheus exporter, the default prometheus registry is used when nil.
+	// NewProviders serve this registry when set, otherwise new dedicated registry is used
+	PrometheusRegistry *promclient.Registry
+	// StatsdOpt option for statsd exporter
+	StatsdOpt StatsdExporterOption
+	// RemoteWriteOpt option for prometheus remote write exporter
+	RemoteWriteOpt RemoteWriteExporterOption
+	// ManualReader reader for manual exporter, new manual reader with ManualReaderOpts is created when nil.
+	// NewProviders set this reader to Providers.ManualReader
+	ManualReader *sdkmetric.ManualReader
+	// ManualReaderOpts option for new manual reader, e.g. sdkmetric.WithTemporalitySelector and sdkmetric.WithProducer
+	ManualReaderOpts []sdkmetric.ManualReaderOption
+	// SeriesLimit limit attribute set per metric stream on export,
+	// for prometheus it is applied by NewProviders MetricsHandler, see NewSeriesLimitGatherer
+	SeriesLimit MetricSeriesLimit
 }
 
 // NewMetricsExporter new metrics exporter with defined type
@@ -50,10 +74,29 @@ type MetricExporterOption struct {
 // Supported values:
 // - "explicit_bucket_histogram" - Explicit Bucket Histogram Aggregation https://github.com/open-telemetry/opentelemetry-specification/blob/v1.26.0/specification/metrics/sdk.md#explicit-bucket-histogram-aggregation,
 // - "base2_exponential_bucket_histogram" - Base2 Exponential Bucket Histogram Aggregation https://github.com/open-telemetry/opentelemetry-specification/blob/v1.26.0/specification/metrics/sdk.md#base2-exponential-bucket-histogram-aggregation.
+// OTEL_EXPORTER_OTLP_METRICS_EXPONENTIAL_HISTOGRAM_MAX_SIZE, OTEL_EXPORTER_OTLP_METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE = (default: "160", "20")
+// used by InitMetricProvider for grpc and http exporter only when default histogram aggregation is base2_exponential_bucket_histogram,
+// The configuration can be overridden by WithAggregationSelector option.
 //
 // stdout just will print out the trace
 //
 // prometheus using prometheus
+//
+// statsd send metric to statsd or DogStatsD agent with delta temporality
+// OTEL_EXPORTER_STATSD_ENDPOINT = (default: "udp://localhost:8125") udp://host:port or unix:///path/to/socket
+// OTEL_EXPORTER_STATSD_PREFIX = (default: none) metric name prefix
+// The configuration can be overridden by opts.StatsdOpt
+//
+// manual collect metric only on demand with opts.ManualReader, e.g. Providers.CollectMetrics for test
+//
+// remotewrite push metric with prometheus remote write protocol with cumulative temporality
+// OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_ENDPOINT = (default: "http://localhost:9009/api/v1/push")
+// OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_HEADERS = (default: none) you can fill with format "key1=value1,key2=value2"
+// OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_USERNAME, OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_PASSWORD = (default: none) basic auth
+// OTEL_EXPORTER_PROMETHEUS_REMOTE_WRITE_BEARER_TOKEN = (default: none)
+// The configuration can be overridden by opts.RemoteWriteOpt
+//
+// opts.SeriesLimit fold attribute set over the limit into otel.metric.overflow=true series for grpc, http, stdout, statsd and remotewrite
 func NewMetricsExporter(ctx context.Context, endpointType MetricExporterType, opts MetricExporterOption) (sdkmetric.Reader, error) {
 	var (
 		exporter sdkmetric.Exporter
@@ -67,8 +110,22 @@ func NewMetricsExporter(ctx context.Context, endpointType MetricExporterType, op
 		exporter, err = otlpmetricgrpc.New(ctx, opts.GrpcOpts...)
 	case StdOutMetricExporter:
 		exporter, err = stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+	case StatsdMetricExporter:
+		exporter, err = NewStatsdExporter(opts.StatsdOpt)
+	case RemoteWriteMetricExporter:
+		exporter, err = NewRemoteWriteExporter(opts.RemoteWriteOpt)
+	case ManualMetricExporter:
+		if opts.ManualReader != nil {
+			return opts.ManualReader, nil
+		}
+
+		return sdkmetric.NewManualReader(opts.ManualReaderOpts...), nil
 	case PrometheusMetricExporter:
-		return prometheus.New()
+		if opts.PrometheusRegistry != nil {
+			return prometheus.New(append([]prometheus.Option{prometheus.WithRegisterer(opts.PrometheusRegistry)}, opts.PrometheusOpts...)...)
+		}
+
+		return prometheus.New(opts.PrometheusOpts...)
 	default:
 		return nil, ErrInvalidMetricExporterType
 	}
@@ -77,10 +134,16 @@ func NewMetricsExporter(ctx context.Context, endpointType MetricExporterType, op
 		return nil, err
 	}
 
+	if opts.SeriesLimit.enabled() {
+		exporter = newSeriesLimitExporter(exporter, opts.SeriesLimit)
+	}
+
 	return sdkmetric.NewPeriodicReader(exporter, opts.ReaderOpts...), nil
 }
 
 // NewMetricProvider initiate provider for metric
+// exemplar filter is set by the SDK from OTEL_METRICS_EXEMPLAR_FILTER env (default: trace_based),
+// override with sdkmetric.WithExemplarFilter option
 func NewMetricProvider(res *resource.Resource, reader sdkmetric.Reader, opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, error) {
 	return sdkmetric.NewMeterProvider(append([]sdkmetric.Option{
 		sdkmetric.WithResource(res),
@@ -88,31 +151,143 @@ func NewMetricProvider(res *resource.Resource, reader sdkmetric.Reader, opts ...
 	}, opts...)...), nil
 }
 
+// NewExemplarFilter create exemplar filter by filter type, exemplar is attached to the exported
+// data point with the trace id and span id of the measurement context
+//
+//	otel.NewMetricProvider(res, reader, sdkmetric.WithExemplarFilter(filter))
+func NewExemplarFilter(filterType ExemplarFilterType) (exemplar.Filter, error) {
+	switch filterType {
+	case AlwaysOnExemplarFilter:
+		return exemplar.AlwaysOnFilter, nil
+	case AlwaysOffExemplarFilter:
+		return exemplar.AlwaysOffFilter, nil
+	case TraceBasedExemplarFilter:
+		return exemplar.TraceBasedFilter, nil
+	}
+
+	return nil, ErrInvalidExemplarFilterType
+}
+
 // SetGlobalMetricProvider set metric provider as global meter provider
 func SetGlobalMetricProvider(metricProvider *sdkmetric.MeterProvider) {
 	otel.SetMeterProvider(metricProvider)
 }
 
-// InitMetricProvider using basic init metric provider without option
+// NewPrometheusHandler create http handler that serve metrics from the prometheus registry
+// with OpenMetrics content negotiation
+func NewPrometheusHandler(registry *promclient.Registry) http.Handler {
+	return newPrometheusHandler(registry, registry)
+}
+
+// newPrometheusHandler create http handler that serve metrics from the gatherer,
+// handler error metric is registered to the registry
+func newPrometheusHandler(registry *promclient.Registry, gatherer promclient.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+		Registry:          registry,
+		EnableOpenMetrics: true,
+	})
+}
+
+// InitMetricProvider using basic init metric provider with optional metric provider option
 // this will do init metric exporter by exporterType argument
-// pass the exporter to metric provider
+// pass the exporter and opts to metric provider
+// views from OTEL_METRICS_VIEWS and OTEL_METRICS_VIEWS_FILE is applied to the metric provider
+// prometheus exporter option from OTEL_EXPORTER_PROMETHEUS_* env is applied to prometheus exporter
+// host and process metrics is started when OTEL_HOST_METRICS is enabled
+// default series limit is set from OTEL_METRICS_SERIES_LIMIT
 // set new metric provider to global
-func InitMetricProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
+func InitMetricProvider(ctx context.Context, res *resource.Resource, opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, error) {
+	return initMetricProvider(ctx, res, &MetricExporterOption{}, nil, opts...)
+}
+
+// initMetricProvider init metric provider, hostMetrics option from OTEL_HOST_METRICS_* env is used when nil.
+// exporterOpt is updated with the option from env so the caller can use the effective series limit
+func initMetricProvider(ctx context.Context, res *resource.Resource, exporterOpt *MetricExporterOption, hostMetrics *HostMetricsOption, opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, error) {
 	exporterType := getMetricExporterTypeFromEnv()
 
 	if exporterType == "" {
 		return nil, nil
 	}
 
-	exporter, err := NewMetricsExporter(ctx, exporterType, MetricExporterOption{})
+	if hostMetrics == nil {
+		var err error
+
+		hostMetrics, err = getHostMetricsOptionFromEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	viewConfigs, err := getMetricViewsFromEnv()
 	if err != nil {
 		return nil, err
 	}
 
-	provider, err := NewMetricProvider(res, exporter)
+	views, err := NewMetricViews(viewConfigs)
 	if err != nil {
 		return nil, err
 	}
 
+	exporterOpt.SeriesLimit.Views = append(viewConfigs, exporterOpt.SeriesLimit.Views...)
+
+	if exporterOpt.SeriesLimit.Default == 0 {
+		exporterOpt.SeriesLimit.Default, err = getSeriesLimitFromEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prometheusOpts, err := getPrometheusOptsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	exponentialHistogram, err := getExponentialHistogramAggregationFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	// exponential histogram size is set on otlp exporter before the option from argument
+	if exponentialHistogram != nil {
+		selector := func(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+			if kind == sdkmetric.InstrumentKindHistogram {
+				return exponentialHistogram
+			}
+
+			return sdkmetric.DefaultAggregationSelector(kind)
+		}
+
+		exporterOpt.GrpcOpts = append([]otlpmetricgrpc.Option{otlpmetricgrpc.WithAggregationSelector(selector)}, exporterOpt.GrpcOpts...)
+		exporterOpt.HttpOpts = append([]otlpmetrichttp.Option{otlpmetrichttp.WithAggregationSelector(selector)}, exporterOpt.HttpOpts...)
+	}
+
+	// option from argument is applied last so it can override the env
+	exporterOpt.PrometheusOpts = append(prometheusOpts, exporterOpt.PrometheusOpts...)
+	exporterOpt.StatsdOpt = getStatsdOptFromEnv(exporterOpt.StatsdOpt)
+	exporterOpt.RemoteWriteOpt = getRemoteWriteOptFromEnv(exporterOpt.RemoteWriteOpt)
+
+	exporter, err := NewMetricsExporter(ctx, exporterType, *exporterOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	// option from argument is applied last so it can add more views
+	if len(views) > 0 {
+		opts = append([]sdkmetric.Option{sdkmetric.WithView(views...)}, opts...)
+	}
+
+	provider, err := NewMetricProvider(res, exporter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// host metrics is stopped together with the provider
+	if hostMetrics != nil {
+		err = NewHostMetrics(*hostMetrics).Start(provider)
+		if err != nil {
+			return nil, errors.Join(err, provider.Shutdown(ctx))
+		}
+	}
+
 	return provider, nil
 }
@@ -14,7 +14,45 @@ const (
 	PrometheusMetricExporter MetricExporterType = "prometheus"
 	// StdOutMetricExporter exporter prometheus type
 	StdOutMetricExporter MetricExporterType = "stdout"
+	// StatsdMetricExporter exporter statsd and DogStatsD type
+	StatsdMetricExporter MetricExporterType = "statsd"
+	// RemoteWriteMetricExporter exporter prometheus remote write type
+	RemoteWriteMetricExporter MetricExporterType = "remotewrite"
+	// ManualMetricExporter exporter manual reader type, metric is only collected on demand
+	ManualMetricExporter MetricExporterType = "manual"
 )
 
-// ErrInvalidMetricExporterType invalid metric exporter type error
-var ErrInvalidMetricExporterType = errors.New("invalid metric exporter type")
+var (
+	// ErrInvalidMetricExporterType invalid metric exporter type error
+	ErrInvalidMetricExporterType = errors.New("invalid metric exporter type")
+	// ErrInvalidStatsdEndpoint invalid statsd endpoint error, supported scheme udp and unix
+	ErrInvalidStatsdEndpoint = errors.New("invalid statsd endpoint")
+	// ErrInvalidRemoteWriteEndpoint invalid remote write endpoint error, supported scheme http and https
+	ErrInvalidRemoteWriteEndpoint = errors.New("invalid remote write endpoint")
+	// ErrInvalidRemoteWriteAuth basic auth and bearer token is set together error
+	ErrInvalidRemoteWriteAuth = errors.New("remote write basic auth and bearer token can't be used together")
+	// ErrRemoteWriteFailed remote write request is rejected error
+	ErrRemoteWriteFailed = errors.New("remote write request failed")
+	// ErrManualMetricReaderNotSet collect metric without manual metric reader error
+	ErrManualMetricReaderNotSet = errors.New("manual metric reader is not set")
+	// ErrMetricSeriesLimit metric stream has more attribute set than the series limit error,
+	// the attribute set over the limit is folded into otel.metric.overflow series
+	ErrMetricSeriesLimit = errors.New("metric series over the limit")
+	// ErrHostMetricsUnsupported start host metrics on non linux os error
+	ErrHostMetricsUnsupported = errors.New("host metrics is only supported on linux")
+)
+
+// ExemplarFilterType filter type of measurement that can be exemplar
+type ExemplarFilterType string
+
+const (
+	// AlwaysOnExemplarFilter all measurement can be exemplar
+	AlwaysOnExemplarFilter ExemplarFilterType = "always_on"
+	// AlwaysOffExemplarFilter disable exemplar
+	AlwaysOffExemplarFilter ExemplarFilterType = "always_off"
+	// TraceBasedExemplarFilter only measurement recorded in sampled span can be exemplar
+	TraceBasedExemplarFilter ExemplarFilterType = "trace_based"
+)
+
+// ErrInvalidExemplarFilterType invalid exemplar filter type error
+var ErrInvalidExemplarFilterType = errors.New("invalid exemplar filter type")
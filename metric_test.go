@@ -0,0 +1,390 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(sampled bool) context.Context {
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: flags,
+	}))
+}
+
+// collectTestHistogram record the values to histogram with bounds 1 and 10 and returns the data point
+func collectTestHistogram(t *testing.T, ctx context.Context, opts []sdkmetric.Option, values ...float64) metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+
+	provider, err := NewMetricProvider(nil, reader, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	histogram, err := provider.Meter("test").Float64Histogram("latency")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range values {
+		histogram.Record(ctx, value)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	data := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+
+	return data.DataPoints[0]
+}
+
+func TestNewMetricProviderExemplar(t *testing.T) {
+	view := sdkmetric.NewView(sdkmetric.Instrument{Name: "latency"}, sdkmetric.Stream{
+		Aggregation: sdkmetric.AggregationExplicitBucketHistogram{Boundaries: []float64{1, 10}},
+	})
+
+	sampled := testSpanContext(true)
+	spanContext := trace.SpanContextFromContext(sampled)
+
+	point := collectTestHistogram(t, sampled, []sdkmetric.Option{sdkmetric.WithView(view)}, 0.5, 5, 50)
+
+	if len(point.Exemplars) != 3 {
+		t.Fatalf("got %d exemplars, want one per bucket", len(point.Exemplars))
+	}
+
+	values := make(map[float64]bool)
+
+	for _, e := range point.Exemplars {
+		if trace.TraceID(e.TraceID) != spanContext.TraceID() || trace.SpanID(e.SpanID) != spanContext.SpanID() {
+			t.Errorf("exemplar %v has trace id %x and span id %x, want %s and %s",
+				e.Value, e.TraceID, e.SpanID, spanContext.TraceID(), spanContext.SpanID())
+		}
+
+		values[e.Value] = true
+	}
+
+	for _, value := range []float64{0.5, 5, 50} {
+		if !values[value] {
+			t.Errorf("bucket exemplar of %v is not recorded", value)
+		}
+	}
+
+	if point := collectTestHistogram(t, testSpanContext(false), nil, 5); len(point.Exemplars) != 0 {
+		t.Errorf("got %d exemplars for not sampled span, want 0 with trace_based filter", len(point.Exemplars))
+	}
+}
+
+func TestNewMetricProviderExemplarFilterEnv(t *testing.T) {
+	t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", string(AlwaysOnExemplarFilter))
+
+	if point := collectTestHistogram(t, context.Background(), nil, 5); len(point.Exemplars) != 1 {
+		t.Errorf("got %d exemplars without span, want 1 with always_on filter", len(point.Exemplars))
+	}
+
+	filter, err := NewExemplarFilter(AlwaysOffExemplarFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	option := []sdkmetric.Option{sdkmetric.WithExemplarFilter(filter)}
+	if point := collectTestHistogram(t, testSpanContext(true), option, 5); len(point.Exemplars) != 0 {
+		t.Errorf("got %d exemplars, want option to override the env", len(point.Exemplars))
+	}
+}
+
+// scrapeTestPrometheus scrape the prometheus handler with the accept header and returns the content type and body
+func scrapeTestPrometheus(t *testing.T, handler http.Handler, accept string) (string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body %s", rec.Code, rec.Body)
+	}
+
+	return rec.Header().Get("Content-Type"), rec.Body.String()
+}
+
+// setTestPrometheusEnv set env so NewProviders only create metric provider with prometheus exporter
+func setTestPrometheusEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(providersEnv, "metric")
+	t.Setenv(exporterTypeEnv, "")
+	t.Setenv(metricExporterTypeEnv, string(PrometheusMetricExporter))
+	t.Setenv(runtimeMetricsEnv, "false")
+}
+
+func TestNewPrometheusHandler(t *testing.T) {
+	registry := promclient.NewRegistry()
+
+	reader, err := NewMetricsExporter(context.Background(), PrometheusMetricExporter, MetricExporterOption{PrometheusRegistry: registry})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewMetricProvider(resource.Empty(), reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	counter, err := provider.Meter("test").Int64Counter("dedicated.registry.requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(context.Background(), 1)
+
+	handler := NewPrometheusHandler(registry)
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantEOF         bool
+	}{
+		{name: "text", wantContentType: "text/plain"},
+		{name: "openmetrics", accept: "application/openmetrics-text; version=1.0.0", wantContentType: "application/openmetrics-text", wantEOF: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body := scrapeTestPrometheus(t, handler, tt.accept)
+
+			if !strings.HasPrefix(contentType, tt.wantContentType) {
+				t.Errorf("got content type %q, want %q", contentType, tt.wantContentType)
+			}
+
+			for _, want := range []string{
+				`dedicated_registry_requests_total{otel_scope_name="test",otel_scope_version=""} 1`,
+				// handler error metric is registered to the same registry
+				"promhttp_metric_handler_errors_total",
+			} {
+				if !strings.Contains(body, want) {
+					t.Errorf("got body %s, want %q", body, want)
+				}
+			}
+
+			if got := strings.HasSuffix(body, "# EOF\n"); got != tt.wantEOF {
+				t.Errorf("got OpenMetrics EOF %v, want %v", got, tt.wantEOF)
+			}
+		})
+	}
+
+	// the metric is only registered to the dedicated registry
+	families, err := promclient.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), "dedicated_registry_") {
+			t.Errorf("got %s in the default registry", family.GetName())
+		}
+	}
+}
+
+func TestNewProvidersPrometheusEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "default",
+			want:    []string{`env_requests_total{otel_scope_name="test",otel_scope_version=""} 1`, "target_info"},
+			notWant: []string{"app_env_requests"},
+		},
+		{
+			name: "namespace",
+			env:  map[string]string{prometheusNamespaceEnv: "app"},
+			want: []string{`app_env_requests_total{otel_scope_name="test",otel_scope_version=""} 1`},
+		},
+		{
+			name:    "without counter suffixes and scope info",
+			env:     map[string]string{prometheusWithoutCounterSuffixesEnv: "true", prometheusWithoutScopeInfoEnv: "true"},
+			want:    []string{"env_requests 1"},
+			notWant: []string{"env_requests_total", "otel_scope_name"},
+		},
+		{
+			name:    "without target info",
+			env:     map[string]string{prometheusWithoutTargetInfoEnv: "true"},
+			notWant: []string{"target_info"},
+		},
+		{
+			name:    "invalid bool",
+			env:     map[string]string{prometheusWithoutUnitsEnv: "yes please"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestPrometheusEnv(t)
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			providers, err := NewProviders(context.Background(), WithPrometheusServer("", ""))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+			t.Cleanup(func() { _ = providers.Shutdown(context.Background()) })
+
+			if providers.metricsServer != nil {
+				t.Error("got metrics server with empty address")
+			}
+
+			counter, err := providers.MetricProvider.Meter("test").Int64Counter("env.requests")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			counter.Add(context.Background(), 1)
+
+			_, body := scrapeTestPrometheus(t, providers.MetricsHandler, "")
+
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("got body %s, want %q", body, want)
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("got body %s, want no %q", body, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestNewProvidersPrometheusServer(t *testing.T) {
+	setTestPrometheusEnv(t)
+	t.Setenv(prometheusHostEnv, "")
+	t.Setenv(prometheusPortEnv, "")
+	t.Setenv(prometheusPathEnv, "")
+
+	// the server start by default on localhost:9464
+	listener, err := net.Listen("tcp", "localhost:9464")
+	if err != nil {
+		t.Skipf("default prometheus port is not available: %v", err)
+	}
+	_ = listener.Close()
+
+	providers, err := NewProviders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = providers.Shutdown(context.Background()) })
+
+	counter, err := providers.MetricProvider.Meter("test").Int64Counter("server.requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(context.Background(), 1)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:9464/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/openmetrics-text") {
+		t.Errorf("got content type %q, want OpenMetrics", resp.Header.Get("Content-Type"))
+	}
+
+	if !strings.Contains(string(body), `server_requests_total{otel_scope_name="test",otel_scope_version=""} 1.0`) {
+		t.Errorf("got body %s, want counter", body)
+	}
+
+	if err := providers.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := http.Get("http://localhost:9464/metrics"); err == nil {
+		t.Error("got response after shutdown, want server stopped")
+	}
+}
+
+func TestPrometheusExemplar(t *testing.T) {
+	registry := promclient.NewRegistry()
+
+	reader, err := NewMetricsExporter(context.Background(), PrometheusMetricExporter, MetricExporterOption{PrometheusRegistry: registry})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewMetricProvider(resource.Empty(), reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	histogram, err := provider.Meter("test").Float64Histogram("exemplar.latency", metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(1, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := testSpanContext(true)
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	histogram.Record(ctx, 5)
+
+	_, body := scrapeTestPrometheus(t, NewPrometheusHandler(registry), "application/openmetrics-text; version=1.0.0")
+
+	want := `exemplar_latency_seconds_bucket{otel_scope_name="test",otel_scope_version="",le="10.0"} 1 # {trace_id="` +
+		spanContext.TraceID().String() + `",span_id="` + spanContext.SpanID().String() + `"} 5.0`
+	if !strings.Contains(body, want) {
+		t.Errorf("got body %s, want exemplar %s", body, want)
+	}
+}
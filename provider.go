@@ -2,9 +2,15 @@ package otel
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/http"
 
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/exporters/prometheus"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -13,12 +19,184 @@ type Providers struct {
 	TraceProvider  *sdktrace.TracerProvider
 	MetricProvider *sdkmetric.MeterProvider
 	LogProvider    *sdklog.LoggerProvider
+	// DebugHandler debug page handler, only set when WithDebugPage option is used
+	DebugHandler *DebugHandler
+	// MetricsHandler prometheus metrics handler, only set when the metric exporter type is prometheus
+	MetricsHandler http.Handler
+	// RuntimeMetrics go runtime metrics, only set when runtime metrics is enabled
+	RuntimeMetrics *RuntimeMetrics
+	// ManualReader manual metric reader, only set when the metric exporter type is manual, see CollectMetrics
+	ManualReader *sdkmetric.ManualReader
+
+	debugServer   *http.Server
+	metricsServer *http.Server
+	stdLogRestore func()
+}
+
+// WithTraceProviderOptions add option for trace provider
+func WithTraceProviderOptions(opts ...sdktrace.TracerProviderOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.traceOpts = append(o.traceOpts, opts...)
+	}
+}
+
+// WithMetricProviderOptions add option for metric provider
+func WithMetricProviderOptions(opts ...sdkmetric.Option) ProvidersOption {
+	return func(o *providersOption) {
+		o.metricOpts = append(o.metricOpts, opts...)
+	}
+}
+
+// WithLogProviderOptions add option for log provider
+func WithLogProviderOptions(opts ...sdklog.LoggerProviderOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.logOpts = append(o.logOpts, opts...)
+	}
+}
+
+// WithMetricExporterOption set option for metric exporter,
+// WithMetricSeriesLimit is applied on top of the option regardless of the order
+func WithMetricExporterOption(opt MetricExporterOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.metricExporterOpt = opt
+	}
+}
+
+// WithLogExporterOption set option for log exporter,
+// WithLogSeverityFilter, WithLogProcessor and WithLogDedup are applied on top of the option regardless of the order
+func WithLogExporterOption(opt LogExporterOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.logExporterOpt = opt
+	}
+}
+
+// WithLogSeverityFilter override OTEL_LOGS_MIN_SEVERITY minimum severity of the exported log,
+// logger is matched before logger from OTEL_LOGS_MIN_SEVERITY_LOGGERS, see NewLogSeverityFilterProcessor
+//
+//	otel.WithLogSeverityFilter(otel.WarnLogSeverity, otel.LoggerSeverity{Logger: "payments", MinSeverity: otel.DebugLogSeverity})
+func WithLogSeverityFilter(minSeverity LogSeverity, loggers ...LoggerSeverity) ProvidersOption {
+	return func(o *providersOption) {
+		o.logSeverityFilter = &LogSeverityFilterOption{MinSeverity: minSeverity, Loggers: loggers}
+	}
+}
+
+// WithLogProcessor override OTEL_BLRP_* batch option of the log processor or use synchronous simple processor, see NewLogProcessor
+//
+//	otel.WithLogProcessor(otel.LogProcessorOption{Simple: true})
+func WithLogProcessor(opt LogProcessorOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.logProcessor = &opt
+	}
+}
+
+// WithLogDedup override OTEL_LOGS_DEDUP_WINDOW, OTEL_LOGS_DEDUP_LIMIT and OTEL_LOGS_SAMPLED_TRACES_ONLY
+// to rate limit identical log record, see NewLogDedupProcessor
+//
+//	otel.WithLogDedup(otel.LogDedupOption{Window: time.Minute, Limit: 10})
+func WithLogDedup(opt LogDedupOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.logDedup = &opt
+	}
+}
+
+// WithStdLogRedirect redirect standard library logger output to the log provider as info record,
+// the previous writer is restored on Shutdown, see RedirectStdLog
+//
+//	otel.WithStdLogRedirect(otel.StdLogOption{Tee: true})
+func WithStdLogRedirect(opt StdLogOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.stdLog = &opt
+	}
+}
+
+// WithLogSpanEvents add every log record as "log" event of the recording span from the emit context,
+// Providers.LogProvider only has the span event processor when log provider is not enabled or log exporter is not set,
+// see NewLogSpanEventProcessor
+func WithLogSpanEvents(opt LogSpanEventOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.logSpanEvents = &opt
+	}
+}
+
+// WithSpanEventLogs emit span event and recorded exception as log record when the span end,
+// the log provider of NewProviders is used when opt.LoggerProvider is nil, see NewSpanEventLogProcessor
+func WithSpanEventLogs(opt SpanEventLogOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.spanEventLogs = &opt
+	}
+}
+
+// WithPrometheusServer override prometheus metrics server address and path from
+// OTEL_EXPORTER_PROMETHEUS_HOST, OTEL_EXPORTER_PROMETHEUS_PORT and OTEL_EXPORTER_PROMETHEUS_PATH.
+// empty path use "/metrics", empty address disable the server, mount Providers.MetricsHandler on your own server instead
+func WithPrometheusServer(address, path string) ProvidersOption {
+	return func(o *providersOption) {
+		if path == "" {
+			path = prometheusPathEnvDefault
+		}
+
+		o.prometheusServer = address != ""
+		o.prometheusServerAddress = address
+		o.prometheusServerPath = path
+	}
+}
+
+// WithExemplarFilter override OTEL_METRICS_EXEMPLAR_FILTER exemplar filter of the metric provider
+func WithExemplarFilter(filterType ExemplarFilterType) ProvidersOption {
+	return func(o *providersOption) {
+		o.exemplarFilter = filterType
+	}
+}
+
+// WithMetricSeriesLimit override OTEL_METRICS_SERIES_LIMIT default series limit of every metric stream,
+// SeriesLimitDisabled is unlimited. per instrument limit is set with MetricViewConfig.SeriesLimit, see MetricSeriesLimit
+func WithMetricSeriesLimit(limit int) ProvidersOption {
+	return func(o *providersOption) {
+		o.metricSeriesLimit = &limit
+	}
+}
+
+// WithRuntimeMetrics override OTEL_GO_RUNTIME_METRICS to enable or disable go runtime metrics
+// on the metric provider, runtime metrics is disabled by default for manual metric exporter, see RuntimeMetrics
+func WithRuntimeMetrics(enabled bool) ProvidersOption {
+	return func(o *providersOption) {
+		o.runtimeMetrics = enabled
+	}
+}
+
+// WithHostMetrics enable linux host and process metrics on the metric provider, see HostMetrics
+func WithHostMetrics(opt HostMetricsOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.hostMetrics = &opt
+	}
+}
+
+// WithSpanCardinalityGuard add cardinality guard span processor to trace provider, see NewCardinalityGuardProcessor
+func WithSpanCardinalityGuard(opt CardinalityGuardOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.traceOpts = append(o.traceOpts, sdktrace.WithSpanProcessor(NewCardinalityGuardProcessor(opt)))
+	}
+}
+
+// WithDebugPage enable debug page that show running spans, latency per span name,
+// the last error spans and effective provider configuration.
+// the page is served on opt.Address (e.g. ":55679") when not empty,
+// otherwise mount Providers.DebugHandler on your own server
+func WithDebugPage(opt DebugPageOption) ProvidersOption {
+	return func(o *providersOption) {
+		o.debugPage = &opt
+	}
 }
 
 // NewProviders init Open Telemetry config
-func NewProviders(ctx context.Context) (*Providers, error) {
+func NewProviders(ctx context.Context, opts ...ProvidersOption) (*Providers, error) {
 	var providers Providers
 
+	option, err := newProvidersOption(opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	providersEnable, err := getProvidersEnable()
 	if err != nil {
 		return nil, err
@@ -29,47 +207,268 @@ func NewProviders(ctx context.Context) (*Providers, error) {
 		return nil, err
 	}
 
+	// exemplar filter is validated before any provider is created and set globally
+	if option.exemplarFilter != "" {
+		exemplarFilter, err := NewExemplarFilter(option.exemplarFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		option.metricOpts = append(option.metricOpts, sdkmetric.WithExemplarFilter(exemplarFilter))
+	}
+
+	var debugProcessor *DebugSpanProcessor
+	if option.debugPage != nil {
+		debugProcessor = NewDebugSpanProcessor(*option.debugPage)
+		option.traceOpts = append(option.traceOpts, sdktrace.WithSpanProcessor(debugProcessor))
+	}
+
 	if providersEnable.Trace {
-		traceProvider, err := InitTraceProvider(ctx, resource)
+		idGeneratorType, err := getTraceIDGeneratorTypeFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		traceProvider, err := initTraceProvider(ctx, resource, idGeneratorType, option.traceOpts...)
 		if err != nil {
 			return nil, err
 		}
 
 		if traceProvider != nil {
 			SetGlobalTraceProvider(traceProvider)
-			SetGlobalContextPropagation()
+			SetGlobalContextPropagation(NewIDGeneratorPropagators(idGeneratorType)...)
 			providers.TraceProvider = traceProvider
 		}
 	}
 
 	if providersEnable.Metric {
-		metricProvider, err := InitMetricProvider(ctx, resource)
+		// serve prometheus from dedicated registry instead of the default prometheus registry
+		if getMetricExporterTypeFromEnv() == PrometheusMetricExporter && option.metricExporterOpt.PrometheusRegistry == nil {
+			option.metricExporterOpt.PrometheusRegistry = promclient.NewRegistry()
+		}
+
+		// runtime histogram is produced to the reader, the rest is started on the provider below
+		if option.runtimeMetrics {
+			providers.RuntimeMetrics = NewRuntimeMetrics()
+			option.metricExporterOpt.ReaderOpts = append(option.metricExporterOpt.ReaderOpts, sdkmetric.WithProducer(providers.RuntimeMetrics))
+			option.metricExporterOpt.PrometheusOpts = append(option.metricExporterOpt.PrometheusOpts, prometheus.WithProducer(providers.RuntimeMetrics))
+			option.metricExporterOpt.ManualReaderOpts = append(option.metricExporterOpt.ManualReaderOpts, sdkmetric.WithProducer(providers.RuntimeMetrics))
+		}
+
+		// manual reader is kept so the metric can be collected with Providers.CollectMetrics
+		if getMetricExporterTypeFromEnv() == ManualMetricExporter && option.metricExporterOpt.ManualReader == nil {
+			option.metricExporterOpt.ManualReader = sdkmetric.NewManualReader(option.metricExporterOpt.ManualReaderOpts...)
+		}
+
+		metricProvider, err := initMetricProvider(ctx, resource, &option.metricExporterOpt, option.hostMetrics, option.metricOpts...)
 		if err != nil {
-			return nil, err
+			return nil, errors.Join(err, providers.Shutdown(ctx))
+		}
+
+		if registry := option.metricExporterOpt.PrometheusRegistry; metricProvider != nil && getMetricExporterTypeFromEnv() == PrometheusMetricExporter {
+			var gatherer promclient.Gatherer = registry
+			if option.metricExporterOpt.SeriesLimit.enabled() {
+				gatherer = NewSeriesLimitGatherer(registry, option.metricExporterOpt.SeriesLimit)
+			}
+
+			providers.MetricsHandler = newPrometheusHandler(registry, gatherer)
 		}
 
 		if metricProvider != nil {
 			SetGlobalMetricProvider(metricProvider)
 			providers.MetricProvider = metricProvider
+
+			if getMetricExporterTypeFromEnv() == ManualMetricExporter {
+				providers.ManualReader = option.metricExporterOpt.ManualReader
+			}
+
+			if providers.RuntimeMetrics != nil {
+				err = providers.RuntimeMetrics.Start(metricProvider)
+				if err != nil {
+					return nil, errors.Join(err, providers.Shutdown(ctx))
+				}
+			}
+		} else {
+			providers.RuntimeMetrics = nil
 		}
 	}
 
+	if option.logSpanEvents != nil {
+		processor, err := NewLogSpanEventProcessor(*option.logSpanEvents)
+		if err != nil {
+			return nil, errors.Join(err, providers.Shutdown(ctx))
+		}
+
+		option.logOpts = append(option.logOpts, sdklog.WithProcessor(processor))
+	}
+
+	var (
+		logProvider *sdklog.LoggerProvider
+		logExported bool
+	)
+
 	if providersEnable.Log {
-		logProvider, err := InitLogProvider(ctx, resource)
+		logProvider, err = initLogProvider(ctx, resource, option.logExporterOpt, option.logOpts...)
 		if err != nil {
-			return nil, err
+			return nil, errors.Join(err, providers.Shutdown(ctx))
+		}
+
+		logExported = logProvider != nil
+	}
+
+	// log is only mirrored as span event when there is no log exporter, e.g. for trace only backend
+	if logProvider == nil && option.logSpanEvents != nil {
+		logProvider = sdklog.NewLoggerProvider(append([]sdklog.LoggerProviderOption{sdklog.WithResource(resource)}, option.logOpts...)...)
+	}
+
+	if logProvider != nil {
+		providers.LogProvider = logProvider
+
+		if option.stdLog != nil {
+			providers.stdLogRestore = RedirectStdLog(logProvider, *option.stdLog)
+		}
+	}
+
+	if option.spanEventLogs != nil && providers.TraceProvider != nil {
+		spanEventLogOpt := *option.spanEventLogs
+		// span event only provider can't export the span event, the global log provider is used instead
+		if spanEventLogOpt.LoggerProvider == nil && logExported {
+			spanEventLogOpt.LoggerProvider = providers.LogProvider
+		}
+
+		providers.TraceProvider.RegisterSpanProcessor(NewSpanEventLogProcessor(spanEventLogOpt))
+	}
+
+	if providers.MetricsHandler != nil && option.prometheusServer {
+		mux := http.NewServeMux()
+		mux.Handle(option.prometheusServerPath, providers.MetricsHandler)
+
+		server, err := newHTTPServer(option.prometheusServerAddress, mux)
+		if err != nil {
+			return nil, errors.Join(err, providers.Shutdown(ctx))
 		}
 
-		if logProvider != nil {
-			providers.LogProvider = logProvider
+		providers.metricsServer = server
+	}
+
+	// debug config is created last so it show the option resolved from env, e.g. the series limit
+	if debugProcessor != nil {
+		providers.DebugHandler = NewDebugHandler(debugProcessor, newDebugConfig(providersEnable, resource, &option))
+	}
+
+	if option.debugPage != nil && option.debugPage.Address != "" {
+		server, err := newHTTPServer(option.debugPage.Address, providers.DebugHandler)
+		if err != nil {
+			return nil, errors.Join(err, providers.Shutdown(ctx))
 		}
+
+		providers.debugServer = server
 	}
 
 	return &providers, nil
 }
 
+// newProvidersOption apply the option over the default from env,
+// narrow option like WithMetricSeriesLimit and WithLogProcessor is applied after the exporter option struct so the order doesn't matter
+func newProvidersOption(opts ...ProvidersOption) (providersOption, error) {
+	var option providersOption
+
+	option.prometheusServer = true
+	option.prometheusServerAddress, option.prometheusServerPath = getPrometheusServerFromEnv()
+
+	runtimeMetrics, err := getRuntimeMetricsEnableFromEnv()
+	if err != nil {
+		return option, err
+	}
+
+	option.runtimeMetrics = runtimeMetrics
+
+	for _, opt := range opts {
+		opt(&option)
+	}
+
+	if option.metricSeriesLimit != nil {
+		option.metricExporterOpt.SeriesLimit.Default = *option.metricSeriesLimit
+	}
+
+	if option.logSeverityFilter != nil {
+		option.logExporterOpt.SeverityFilter = *option.logSeverityFilter
+	}
+
+	if option.logProcessor != nil {
+		option.logExporterOpt.Processor = *option.logProcessor
+	}
+
+	if option.logDedup != nil {
+		option.logExporterOpt.Dedup = *option.logDedup
+	}
+
+	return option, nil
+}
+
+// newHTTPServer listen to the address and serve the handler in background
+func newHTTPServer(address string, handler http.Handler) (*http.Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{Handler: handler}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return server, nil
+}
+
+// CollectMetrics collect metric snapshot on demand from the manual metric reader,
+// render it with FormatMetricSnapshot or compare it with DiffMetricSnapshots
+//
+//	rm, err := providers.CollectMetrics(ctx)
+func (o *Providers) CollectMetrics(ctx context.Context) (*metricdata.ResourceMetrics, error) {
+	if o.ManualReader == nil {
+		return nil, ErrManualMetricReaderNotSet
+	}
+
+	var rm metricdata.ResourceMetrics
+
+	err := o.ManualReader.Collect(ctx, &rm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rm, nil
+}
+
 // Shutdown turn of trace and metric
 func (o *Providers) Shutdown(ctx context.Context) error {
+	if o.stdLogRestore != nil {
+		o.stdLogRestore()
+		o.stdLogRestore = nil
+	}
+
+	if o.metricsServer != nil {
+		err := o.metricsServer.Shutdown(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.debugServer != nil {
+		err := o.debugServer.Shutdown(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.RuntimeMetrics != nil {
+		err := o.RuntimeMetrics.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
 	if o.TraceProvider != nil {
 		err := o.TraceProvider.Shutdown(ctx)
 		if err != nil {
@@ -1,6 +1,12 @@
 package otel
 
-import "errors"
+import (
+	"errors"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
 
 type ProviderType string
 
@@ -26,3 +32,32 @@ type ProvidersEnable struct {
 	Metric bool
 	Log    bool
 }
+
+// ProvidersOption option for NewProviders
+type ProvidersOption func(o *providersOption)
+
+type providersOption struct {
+	traceOpts  []sdktrace.TracerProviderOption
+	metricOpts []sdkmetric.Option
+	logOpts    []sdklog.LoggerProviderOption
+
+	metricExporterOpt MetricExporterOption
+	logExporterOpt    LogExporterOption
+	metricSeriesLimit *int
+	logSeverityFilter *LogSeverityFilterOption
+	logProcessor      *LogProcessorOption
+	logDedup          *LogDedupOption
+
+	prometheusServer        bool
+	prometheusServerAddress string
+	prometheusServerPath    string
+
+	exemplarFilter ExemplarFilterType
+	runtimeMetrics bool
+	hostMetrics    *HostMetricsOption
+	stdLog         *StdLogOption
+	logSpanEvents  *LogSpanEventOption
+	spanEventLogs  *SpanEventLogOption
+
+	debugPage *DebugPageOption
+}
@@ -0,0 +1,222 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNewProvidersErrorShutdown(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts []ProvidersOption
+		want error
+	}{
+		{
+			name: "invalid exemplar filter",
+			env:  map[string]string{providersEnv: "trace,metric", metricExporterTypeEnv: string(ManualMetricExporter)},
+			opts: []ProvidersOption{WithExemplarFilter("sometimes")},
+			want: ErrInvalidExemplarFilterType,
+		},
+		{
+			name: "invalid metric exporter",
+			env:  map[string]string{providersEnv: "trace,metric", metricExporterTypeEnv: "nope"},
+			want: ErrInvalidMetricExporterType,
+		},
+		{
+			name: "invalid log exporter",
+			env:  map[string]string{providersEnv: "trace,log", logExporterTypeEnv: "nope"},
+			want: ErrInvalidLogExporterType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// global provider and propagator is restored by the helper, noop provider never record
+			setTestTracerProvider(t)
+			otel.SetTracerProvider(noop.NewTracerProvider())
+			t.Setenv(exporterTypeEnv, "")
+			t.Setenv(traceExporterTypeEnv, string(StdOutTraceExporter))
+			t.Setenv(metricExporterTypeEnv, "")
+			t.Setenv(logExporterTypeEnv, "")
+			t.Setenv(runtimeMetricsEnv, "false")
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			providers, err := NewProviders(context.Background(), tt.opts...)
+			if !errors.Is(err, tt.want) || providers != nil {
+				t.Fatalf("got %v, %v, want error %v", providers, err, tt.want)
+			}
+
+			// trace provider created before the error is shut down
+			_, span := otel.Tracer("test").Start(context.Background(), "test")
+			defer span.End()
+
+			if span.IsRecording() {
+				t.Error("got recording span from the global trace provider, want provider shut down or not replaced")
+			}
+		})
+	}
+}
+
+func TestNewProvidersOptionMetricExporter(t *testing.T) {
+	registry := promclient.NewRegistry()
+
+	tests := []struct {
+		name string
+		opts []ProvidersOption
+	}{
+		{
+			name: "series limit before exporter option",
+			opts: []ProvidersOption{WithMetricSeriesLimit(10), WithMetricExporterOption(MetricExporterOption{PrometheusRegistry: registry})},
+		},
+		{
+			name: "series limit after exporter option",
+			opts: []ProvidersOption{WithMetricExporterOption(MetricExporterOption{PrometheusRegistry: registry}), WithMetricSeriesLimit(10)},
+		},
+		{
+			name: "series limit override exporter option",
+			opts: []ProvidersOption{WithMetricSeriesLimit(10), WithMetricExporterOption(MetricExporterOption{
+				PrometheusRegistry: registry,
+				SeriesLimit:        MetricSeriesLimit{Default: 20},
+			})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option, err := newProvidersOption(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if option.metricExporterOpt.SeriesLimit.Default != 10 {
+				t.Errorf("got series limit %d, want 10", option.metricExporterOpt.SeriesLimit.Default)
+			}
+
+			if option.metricExporterOpt.PrometheusRegistry != registry {
+				t.Error("got exporter option dropped")
+			}
+		})
+	}
+}
+
+func TestNewProvidersOptionLogExporter(t *testing.T) {
+	var (
+		syslog    = SyslogExporterOption{Endpoint: "udp://localhost:514"}
+		processor = LogProcessorOption{Simple: true}
+		dedup     = LogDedupOption{Window: time.Minute, Limit: 10}
+		narrow    = []ProvidersOption{
+			WithLogSeverityFilter(WarnLogSeverity, LoggerSeverity{Logger: "payments", MinSeverity: DebugLogSeverity}),
+			WithLogProcessor(processor),
+			WithLogDedup(dedup),
+		}
+		exporter = WithLogExporterOption(LogExporterOption{
+			SyslogOpt: syslog,
+			Processor: LogProcessorOption{MaxQueueSize: 100},
+			Dedup:     LogDedupOption{Limit: 1},
+		})
+	)
+
+	tests := []struct {
+		name string
+		opts []ProvidersOption
+	}{
+		{name: "narrow option before exporter option", opts: append(append([]ProvidersOption(nil), narrow...), exporter)},
+		{name: "narrow option after exporter option", opts: append([]ProvidersOption{exporter}, narrow...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option, err := newProvidersOption(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := option.logExporterOpt
+
+			if got.SeverityFilter.MinSeverity != WarnLogSeverity || len(got.SeverityFilter.Loggers) != 1 {
+				t.Errorf("got severity filter %+v, want WithLogSeverityFilter", got.SeverityFilter)
+			}
+
+			if got.Processor != processor {
+				t.Errorf("got processor %+v, want %+v", got.Processor, processor)
+			}
+
+			if got.Dedup != dedup {
+				t.Errorf("got dedup %+v, want %+v", got.Dedup, dedup)
+			}
+
+			if got.SyslogOpt.Endpoint != syslog.Endpoint {
+				t.Error("got exporter option dropped")
+			}
+		})
+	}
+}
+
+func TestNewProvidersManualRuntimeMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		opts        []ProvidersOption
+		wantRuntime bool
+	}{
+		{name: "default"},
+		{name: "env", env: "true", wantRuntime: true},
+		{name: "option", opts: []ProvidersOption{WithRuntimeMetrics(true)}, wantRuntime: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(providersEnv, "metric")
+			t.Setenv(exporterTypeEnv, "")
+			t.Setenv(metricExporterTypeEnv, string(ManualMetricExporter))
+			t.Setenv(runtimeMetricsEnv, tt.env)
+
+			providers, err := NewProviders(context.Background(), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = providers.Shutdown(context.Background()) })
+
+			if (providers.RuntimeMetrics != nil) != tt.wantRuntime {
+				t.Fatalf("got runtime metrics %v, want %v", providers.RuntimeMetrics != nil, tt.wantRuntime)
+			}
+
+			counter, err := providers.MetricProvider.Meter("test").Int64Counter("requests")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			counter.Add(context.Background(), 1)
+
+			first, err := providers.CollectMetrics(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			second, err := providers.CollectMetrics(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot := FormatMetricSnapshot(first)
+			if got := strings.Contains(snapshot, "go.schedule.duration"); got != tt.wantRuntime {
+				t.Errorf("got runtime metrics in snapshot %v, want %v:\n%s", got, tt.wantRuntime, snapshot)
+			}
+
+			if diff := DiffMetricSnapshots(first, second); !tt.wantRuntime && diff != "" {
+				t.Errorf("got snapshot diff without new measurement:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,644 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// default remote write exporter setting
+const (
+	remoteWriteEndpointDefault       = "http://localhost:9009/api/v1/push"
+	remoteWriteTimeoutDefault        = 10 * time.Second
+	remoteWriteMaxRetriesDefault     = 3
+	remoteWriteInitialBackoffDefault = 500 * time.Millisecond
+	remoteWriteMaxBackoffDefault     = 5 * time.Second
+	// remoteWriteErrorBodyLimit maximum response body included in the error
+	remoteWriteErrorBodyLimit = 512
+)
+
+// prometheus label set by remote write exporter
+const (
+	prometheusNameLabel     = "__name__"
+	prometheusJobLabel      = "job"
+	prometheusInstanceLabel = "instance"
+	prometheusBucketLabel   = "le"
+	prometheusTargetInfo    = "target_info"
+	// prometheusExportedLabelPrefix prefix of attribute label that clash with the label set by the exporter
+	prometheusExportedLabelPrefix = "exported_"
+)
+
+// remote write metric metadata type from prometheus remote.proto
+const (
+	remoteWriteCounterType   = 1
+	remoteWriteGaugeType     = 2
+	remoteWriteHistogramType = 3
+)
+
+// remoteWriteUnitSuffixes unit suffix appended to the metric name, same as prometheus exporter
+var remoteWriteUnitSuffixes = map[string]string{
+	"d":    "_days",
+	"h":    "_hours",
+	"min":  "_minutes",
+	"s":    "_seconds",
+	"ms":   "_milliseconds",
+	"us":   "_microseconds",
+	"ns":   "_nanoseconds",
+	"By":   "_bytes",
+	"KiBy": "_kibibytes",
+	"MiBy": "_mebibytes",
+	"GiBy": "_gibibytes",
+	"TiBy": "_tibibytes",
+	"KBy":  "_kilobytes",
+	"MBy":  "_megabytes",
+	"GBy":  "_gigabytes",
+	"TBy":  "_terabytes",
+	"m":    "_meters",
+	"V":    "_volts",
+	"A":    "_amperes",
+	"J":    "_joules",
+	"W":    "_watts",
+	"g":    "_grams",
+	"Cel":  "_celsius",
+	"Hz":   "_hertz",
+	"1":    "_ratio",
+	"%":    "_percent",
+}
+
+var (
+	prometheusInvalidMetricNameChar = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+	prometheusInvalidLabelNameChar  = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+var _ sdkmetric.Exporter = (*RemoteWriteExporter)(nil)
+
+// RemoteWriteExporterOption option for prometheus remote write exporter
+type RemoteWriteExporterOption struct {
+	// Endpoint remote write url (default: http://localhost:9009/api/v1/push)
+	Endpoint string
+	// Headers additional http header sent on every request, e.g. X-Scope-OrgID for multi tenant mimir
+	Headers map[string]string
+	// Username and Password basic auth credential
+	Username string
+	Password string
+	// BearerToken bearer token sent as authorization header, can't be used together with basic auth
+	BearerToken string
+	// Timeout timeout of each request (default: 10s)
+	Timeout time.Duration
+	// MaxRetries maximum retry of failed request on network error, 429 and 5xx status, negative disable retry (default: 3)
+	MaxRetries int
+	// InitialBackoff wait before the first retry, doubled on every retry (default: 500ms)
+	InitialBackoff time.Duration
+	// MaxBackoff maximum wait between retry (default: 5s)
+	MaxBackoff time.Duration
+	// Client http client to send the request, http.DefaultClient is used when nil
+	Client *http.Client
+}
+
+// RemoteWriteExporter metric exporter that push metric with prometheus remote write 1.0 protocol,
+// protobuf WriteRequest compressed with snappy. it use cumulative temporality,
+// the metric and label is named like prometheus exporter and exponential histogram is dropped
+type RemoteWriteExporter struct {
+	opt      RemoteWriteExporterOption
+	endpoint string
+}
+
+// remoteWriteLabel prometheus label
+type remoteWriteLabel struct {
+	name  string
+	value string
+}
+
+// remoteWriteSeries prometheus time series with single sample
+type remoteWriteSeries struct {
+	labels    []remoteWriteLabel
+	value     float64
+	timestamp int64
+}
+
+// remoteWriteMetadata prometheus metric family metadata
+type remoteWriteMetadata struct {
+	metricType int
+	name       string
+	help       string
+	unit       string
+}
+
+// remoteWriteRequest prometheus remote write WriteRequest
+type remoteWriteRequest struct {
+	series   []remoteWriteSeries
+	metadata []remoteWriteMetadata
+	// metadataNames metric family that already has metadata
+	metadataNames map[string]bool
+}
+
+// remoteWriteRetryableError failed request that can be retried
+type remoteWriteRetryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *remoteWriteRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *remoteWriteRetryableError) Unwrap() error {
+	return e.err
+}
+
+// NewRemoteWriteExporter create prometheus remote write metric exporter
+//
+//	exporter, err := otel.NewRemoteWriteExporter(otel.RemoteWriteExporterOption{
+//		Endpoint: "https://prometheus-prod.grafana.net/api/prom/push",
+//		Username: "123456",
+//		Password: os.Getenv("GRAFANA_CLOUD_TOKEN"),
+//	})
+//	reader := sdkmetric.NewPeriodicReader(exporter)
+func NewRemoteWriteExporter(opt RemoteWriteExporterOption) (*RemoteWriteExporter, error) {
+	if opt.Endpoint == "" {
+		opt.Endpoint = remoteWriteEndpointDefault
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRemoteWriteEndpoint, err)
+	}
+
+	if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRemoteWriteEndpoint, opt.Endpoint)
+	}
+
+	if opt.BearerToken != "" && (opt.Username != "" || opt.Password != "") {
+		return nil, ErrInvalidRemoteWriteAuth
+	}
+
+	if opt.Timeout <= 0 {
+		opt.Timeout = remoteWriteTimeoutDefault
+	}
+
+	if opt.MaxRetries == 0 {
+		opt.MaxRetries = remoteWriteMaxRetriesDefault
+	}
+
+	if opt.InitialBackoff <= 0 {
+		opt.InitialBackoff = remoteWriteInitialBackoffDefault
+	}
+
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = remoteWriteMaxBackoffDefault
+	}
+
+	if opt.Client == nil {
+		opt.Client = http.DefaultClient
+	}
+
+	return &RemoteWriteExporter{opt: opt, endpoint: endpoint.String()}, nil
+}
+
+// Temporality returns cumulative, prometheus only support cumulative counter and histogram
+func (e *RemoteWriteExporter) Temporality(sdkmetric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+// Aggregation returns default aggregation of the instrument kind
+func (e *RemoteWriteExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export convert the metric to remote write request and send it, failed request is retried with exponential backoff
+func (e *RemoteWriteExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	request := newRemoteWriteRequest(rm)
+	if len(request.series) == 0 {
+		return nil
+	}
+
+	return e.send(ctx, s2.EncodeSnappy(nil, request.marshal()))
+}
+
+// ForceFlush do nothing, metric is sent on export
+func (e *RemoteWriteExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown close idle connection of the http client
+func (e *RemoteWriteExporter) Shutdown(context.Context) error {
+	e.opt.Client.CloseIdleConnections()
+
+	return nil
+}
+
+// send post the body, retry on retryable error until MaxRetries
+func (e *RemoteWriteExporter) send(ctx context.Context, body []byte) error {
+	backoff := e.opt.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := e.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		var retryable *remoteWriteRetryableError
+		if !errors.As(err, &retryable) || attempt >= e.opt.MaxRetries {
+			return err
+		}
+
+		wait := max(backoff, retryable.retryAfter)
+		backoff = min(backoff*2, e.opt.MaxBackoff)
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// post send single remote write request
+func (e *RemoteWriteExporter) post(ctx context.Context, body []byte) error {
+	requestCtx, cancel := context.WithTimeout(ctx, e.opt.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", instrumentationName)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	for key, value := range e.opt.Headers {
+		req.Header.Set(key, value)
+	}
+
+	switch {
+	case e.opt.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+e.opt.BearerToken)
+	case e.opt.Username != "" || e.opt.Password != "":
+		req.SetBasicAuth(e.opt.Username, e.opt.Password)
+	}
+
+	resp, err := e.opt.Client.Do(req)
+	if err != nil {
+		// error of the caller context is not retried, request timeout is retried
+		if ctx.Err() != nil {
+			return err
+		}
+
+		return &remoteWriteRetryableError{err: err}
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, remoteWriteErrorBodyLimit))
+	err = fmt.Errorf("%w: %s: %s", ErrRemoteWriteFailed, resp.Status, bytes.TrimSpace(message))
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5 {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+
+		return &remoteWriteRetryableError{err: err, retryAfter: time.Duration(retryAfter) * time.Second}
+	}
+
+	return err
+}
+
+// newRemoteWriteRequest convert resource metrics to remote write request,
+// resource is converted to job and instance label and target_info series
+func newRemoteWriteRequest(rm *metricdata.ResourceMetrics) *remoteWriteRequest {
+	var (
+		request        = &remoteWriteRequest{metadataNames: make(map[string]bool)}
+		resourceLabels []remoteWriteLabel
+		targetInfo     []remoteWriteLabel
+		lastTimestamp  int64
+	)
+
+	if rm.Resource != nil {
+		resourceLabels, targetInfo = remoteWriteResourceLabels(rm.Resource.Set())
+	}
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		scopeLabels := append(resourceLabels[:len(resourceLabels):len(resourceLabels)],
+			remoteWriteLabel{name: prometheusScopeNameLabel, value: scopeMetrics.Scope.Name})
+
+		if scopeMetrics.Scope.Version != "" {
+			scopeLabels = append(scopeLabels, remoteWriteLabel{name: prometheusScopeVersionLabel, value: scopeMetrics.Scope.Version})
+		}
+
+		for _, m := range scopeMetrics.Metrics {
+			request.appendMetric(m, scopeLabels)
+		}
+	}
+
+	for _, series := range request.series {
+		lastTimestamp = max(lastTimestamp, series.timestamp)
+	}
+
+	if len(request.series) > 0 && len(targetInfo) > 0 {
+		request.appendSeries(prometheusTargetInfo, targetInfo, 1, lastTimestamp)
+		request.appendMetadata(remoteWriteGaugeType, prometheusTargetInfo, "Target metadata", "")
+	}
+
+	return request
+}
+
+// remoteWriteResourceLabels returns job and instance label of the resource,
+// and target_info label that contain the other resource attribute
+func remoteWriteResourceLabels(attrs *attribute.Set) (labels, targetInfo []remoteWriteLabel) {
+	var job string
+
+	if serviceName, ok := attrs.Value(semconv.ServiceNameKey); ok {
+		job = serviceName.Emit()
+	}
+
+	if namespace, ok := attrs.Value(semconv.ServiceNamespaceKey); ok && job != "" {
+		job = namespace.Emit() + "/" + job
+	}
+
+	if job != "" {
+		labels = append(labels, remoteWriteLabel{name: prometheusJobLabel, value: job})
+	}
+
+	if instance, ok := attrs.Value(semconv.ServiceInstanceIDKey); ok {
+		labels = append(labels, remoteWriteLabel{name: prometheusInstanceLabel, value: instance.Emit()})
+	}
+
+	for _, attr := range attrs.ToSlice() {
+		switch attr.Key {
+		case semconv.ServiceNameKey, semconv.ServiceNamespaceKey, semconv.ServiceInstanceIDKey:
+			continue
+		}
+
+		targetInfo = append(targetInfo, remoteWriteLabel{name: remoteWriteAttributeLabelName(string(attr.Key), labels), value: attr.Value.Emit()})
+	}
+
+	if len(targetInfo) > 0 {
+		targetInfo = append(targetInfo, labels...)
+	}
+
+	return labels, targetInfo
+}
+
+// appendMetric append time series of the metric data point
+func (r *remoteWriteRequest) appendMetric(m metricdata.Metrics, scopeLabels []remoteWriteLabel) {
+	switch d := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		appendRemoteWriteSum(r, m, d, scopeLabels)
+	case metricdata.Sum[float64]:
+		appendRemoteWriteSum(r, m, d, scopeLabels)
+	case metricdata.Gauge[int64]:
+		appendRemoteWriteGauge(r, m, d, scopeLabels)
+	case metricdata.Gauge[float64]:
+		appendRemoteWriteGauge(r, m, d, scopeLabels)
+	case metricdata.Histogram[int64]:
+		appendRemoteWriteHistogram(r, m, d, scopeLabels)
+	case metricdata.Histogram[float64]:
+		appendRemoteWriteHistogram(r, m, d, scopeLabels)
+	}
+}
+
+// appendRemoteWriteSum append monotonic cumulative sum as counter and the other sum as gauge
+func appendRemoteWriteSum[N int64 | float64](r *remoteWriteRequest, m metricdata.Metrics, d metricdata.Sum[N], scopeLabels []remoteWriteLabel) {
+	if !d.IsMonotonic {
+		appendRemoteWriteGauge(r, m, metricdata.Gauge[N]{DataPoints: d.DataPoints}, scopeLabels)
+		return
+	}
+
+	// delta sum can't be represented as prometheus counter
+	if d.Temporality != metricdata.CumulativeTemporality {
+		return
+	}
+
+	name := remoteWriteMetricName(m, true)
+	r.appendMetadata(remoteWriteCounterType, name, m.Description, m.Unit)
+
+	for _, p := range d.DataPoints {
+		r.appendSeries(name, remoteWriteLabels(scopeLabels, p.Attributes), float64(p.Value), p.Time.UnixMilli())
+	}
+}
+
+func appendRemoteWriteGauge[N int64 | float64](r *remoteWriteRequest, m metricdata.Metrics, d metricdata.Gauge[N], scopeLabels []remoteWriteLabel) {
+	name := remoteWriteMetricName(m, false)
+	r.appendMetadata(remoteWriteGaugeType, name, m.Description, m.Unit)
+
+	for _, p := range d.DataPoints {
+		r.appendSeries(name, remoteWriteLabels(scopeLabels, p.Attributes), float64(p.Value), p.Time.UnixMilli())
+	}
+}
+
+// appendRemoteWriteHistogram append _bucket series with cumulative count for each le, _sum and _count series
+func appendRemoteWriteHistogram[N int64 | float64](r *remoteWriteRequest, m metricdata.Metrics, d metricdata.Histogram[N], scopeLabels []remoteWriteLabel) {
+	if d.Temporality != metricdata.CumulativeTemporality {
+		return
+	}
+
+	name := remoteWriteMetricName(m, false)
+	r.appendMetadata(remoteWriteHistogramType, name, m.Description, m.Unit)
+
+	for _, p := range d.DataPoints {
+		var (
+			labels     = remoteWriteLabels(scopeLabels, p.Attributes)
+			timestamp  = p.Time.UnixMilli()
+			cumulative uint64
+		)
+
+		for i, count := range p.BucketCounts {
+			le := "+Inf"
+			if i < len(p.Bounds) {
+				le = strconv.FormatFloat(p.Bounds[i], 'g', -1, 64)
+			}
+
+			cumulative += count
+
+			bucketLabels := append(labels[:len(labels):len(labels)], remoteWriteLabel{name: prometheusBucketLabel, value: le})
+			r.appendSeries(name+"_bucket", bucketLabels, float64(cumulative), timestamp)
+		}
+
+		r.appendSeries(name+"_sum", labels, float64(p.Sum), timestamp)
+		r.appendSeries(name+"_count", labels, float64(p.Count), timestamp)
+	}
+}
+
+// appendMetadata append metadata of the metric family once
+func (r *remoteWriteRequest) appendMetadata(metricType int, name, help, unit string) {
+	if r.metadataNames[name] {
+		return
+	}
+
+	r.metadataNames[name] = true
+	r.metadata = append(r.metadata, remoteWriteMetadata{metricType: metricType, name: name, help: help, unit: unit})
+}
+
+// appendSeries append series with __name__ label, the label is sorted by name as required by remote write
+func (r *remoteWriteRequest) appendSeries(name string, labels []remoteWriteLabel, value float64, timestamp int64) {
+	seriesLabels := make([]remoteWriteLabel, 0, len(labels)+1)
+	seriesLabels = append(seriesLabels, remoteWriteLabel{name: prometheusNameLabel, value: name})
+	seriesLabels = append(seriesLabels, labels...)
+
+	sort.SliceStable(seriesLabels, func(i, j int) bool {
+		return seriesLabels[i].name < seriesLabels[j].name
+	})
+
+	r.series = append(r.series, remoteWriteSeries{labels: seriesLabels, value: value, timestamp: timestamp})
+}
+
+// remoteWriteLabels returns scope label and sanitized attribute label,
+// value of attributes with the same sanitized name is joined with ";"
+// and attribute that clash with job, instance, otel_scope_* or le label is renamed to exported_<name>
+func remoteWriteLabels(scopeLabels []remoteWriteLabel, attrs attribute.Set) []remoteWriteLabel {
+	labels := make([]remoteWriteLabel, 0, len(scopeLabels)+attrs.Len())
+	labels = append(labels, scopeLabels...)
+
+	index := make(map[string]int, attrs.Len())
+
+	for _, attr := range attrs.ToSlice() {
+		name := remoteWriteAttributeLabelName(string(attr.Key), scopeLabels)
+
+		if i, ok := index[name]; ok {
+			labels[i].value += ";" + attr.Value.Emit()
+			continue
+		}
+
+		index[name] = len(labels)
+		labels = append(labels, remoteWriteLabel{name: name, value: attr.Value.Emit()})
+	}
+
+	return labels
+}
+
+// remoteWriteMetricName returns sanitized metric name followed by unit suffix and _total suffix for counter
+func remoteWriteMetricName(m metricdata.Metrics, counter bool) string {
+	name := prometheusInvalidMetricNameChar.ReplaceAllString(m.Name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	if counter {
+		name = strings.TrimSuffix(name, "_total")
+	}
+
+	if suffix, ok := remoteWriteUnitSuffixes[m.Unit]; ok && !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+
+	if counter {
+		name += "_total"
+	}
+
+	return name
+}
+
+// remoteWriteAttributeLabelName returns sanitized label name of the attribute,
+// name of __name__, le or the reserved label is prefixed with exported_ so the series has no duplicate label
+func remoteWriteAttributeLabelName(key string, reserved []remoteWriteLabel) string {
+	name := remoteWriteLabelName(key)
+	if name == prometheusNameLabel || name == prometheusBucketLabel {
+		return prometheusExportedLabelPrefix + name
+	}
+
+	for _, label := range reserved {
+		if label.name == name {
+			return prometheusExportedLabelPrefix + name
+		}
+	}
+
+	return name
+}
+
+// remoteWriteLabelName returns sanitized label name, name started with digit is prefixed with key_
+func remoteWriteLabelName(key string) string {
+	name := prometheusInvalidLabelNameChar.ReplaceAllString(key, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "key_" + name
+	}
+
+	return name
+}
+
+// marshal encode the request as prometheus.WriteRequest protobuf
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; repeated MetricMetadata metadata = 3; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+//	message MetricMetadata { MetricType type = 1; string metric_family_name = 2; string help = 4; string unit = 5; }
+func (r *remoteWriteRequest) marshal() []byte {
+	var b, series, label, sample []byte
+
+	for _, s := range r.series {
+		series = series[:0]
+
+		for _, l := range s.labels {
+			label = label[:0]
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, l.name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, l.value)
+
+			series = protowire.AppendTag(series, 1, protowire.BytesType)
+			series = protowire.AppendBytes(series, label)
+		}
+
+		sample = sample[:0]
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+
+		series = protowire.AppendTag(series, 2, protowire.BytesType)
+		series = protowire.AppendBytes(series, sample)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, series)
+	}
+
+	var metadata []byte
+
+	for _, m := range r.metadata {
+		metadata = metadata[:0]
+		metadata = protowire.AppendTag(metadata, 1, protowire.VarintType)
+		metadata = protowire.AppendVarint(metadata, uint64(m.metricType))
+		metadata = protowire.AppendTag(metadata, 2, protowire.BytesType)
+		metadata = protowire.AppendString(metadata, m.name)
+
+		if m.help != "" {
+			metadata = protowire.AppendTag(metadata, 4, protowire.BytesType)
+			metadata = protowire.AppendString(metadata, m.help)
+		}
+
+		if m.unit != "" {
+			metadata = protowire.AppendTag(metadata, 5, protowire.BytesType)
+			metadata = protowire.AppendString(metadata, m.unit)
+		}
+
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, metadata)
+	}
+
+	return b
+}
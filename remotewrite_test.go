@@ -0,0 +1,399 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// testRemoteWriteSeries decoded time series of remote write request
+type testRemoteWriteSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// testRemoteWriteReceiver remote write server that decode every request
+type testRemoteWriteReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	series   []testRemoteWriteSeries
+	// status response status of each request, 204 after the last status
+	status     []int
+	retryAfter string
+}
+
+func (r *testRemoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+
+	status := http.StatusNoContent
+	if len(r.requests) <= len(r.status) {
+		status = r.status[len(r.requests)-1]
+	}
+
+	if status != http.StatusNoContent {
+		w.Header().Set("Retry-After", r.retryAfter)
+		http.Error(w, http.StatusText(status), status)
+
+		return
+	}
+
+	compressed, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := s2.Decode(nil, compressed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := decodeTestRemoteWriteRequest(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.series = append(r.series, series...)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeTestRemoteWriteRequest decode timeseries of prometheus.WriteRequest protobuf
+func decodeTestRemoteWriteRequest(b []byte) ([]testRemoteWriteSeries, error) {
+	var series []testRemoteWriteSeries
+
+	err := walkTestProtobuf(b, func(num protowire.Number, value []byte) error {
+		if num != 1 {
+			return nil
+		}
+
+		s := testRemoteWriteSeries{labels: make(map[string]string)}
+
+		err := walkTestProtobuf(value, func(num protowire.Number, value []byte) error {
+			switch num {
+			case 1:
+				var name, labelValue string
+
+				err := walkTestProtobuf(value, func(num protowire.Number, value []byte) error {
+					if num == 1 {
+						name = string(value)
+					} else {
+						labelValue = string(value)
+					}
+
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				if _, ok := s.labels[name]; ok {
+					return errors.New("duplicate label " + name)
+				}
+
+				s.labels[name] = labelValue
+			case 2:
+				for len(value) > 0 {
+					num, typ, n := protowire.ConsumeTag(value)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+
+					value = value[n:]
+
+					switch {
+					case num == 1 && typ == protowire.Fixed64Type:
+						bits, m := protowire.ConsumeFixed64(value)
+						s.value, n = math.Float64frombits(bits), m
+					case num == 2 && typ == protowire.VarintType:
+						timestamp, m := protowire.ConsumeVarint(value)
+						s.timestamp, n = int64(timestamp), m
+					default:
+						n = protowire.ConsumeFieldValue(num, typ, value)
+					}
+
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+
+					value = value[n:]
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		series = append(series, s)
+
+		return nil
+	})
+
+	return series, err
+}
+
+// walkTestProtobuf call fn for every length delimited field of the message
+func walkTestProtobuf(b []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		b = b[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+
+			b = b[n:]
+
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		if err := fn(num, value); err != nil {
+			return err
+		}
+
+		b = b[n:]
+	}
+
+	return nil
+}
+
+func testRemoteWriteMetrics(now time.Time) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(
+			semconv.ServiceName("payments"),
+			semconv.ServiceInstanceID("i-1"),
+			semconv.HostName("node-1"),
+		),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "shop"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "http.requests",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{{
+							Attributes: attribute.NewSet(attribute.String("job", "batch"), attribute.String("http.method", "GET")),
+							Time:       now,
+							Value:      7,
+						}},
+					},
+				},
+				{
+					Name: "latency",
+					Unit: "s",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attribute.NewSet(attribute.String("le", "x")),
+							Time:         now,
+							Bounds:       []float64{0.1, 1},
+							BucketCounts: []uint64{1, 2, 3},
+							Count:        6,
+							Sum:          9.5,
+						}},
+					},
+				},
+			},
+		}},
+	}
+}
+
+func TestRemoteWriteExporterExport(t *testing.T) {
+	receiver := &testRemoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	exporter, err := NewRemoteWriteExporter(RemoteWriteExporterOption{Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.UnixMilli(1700000000000)
+
+	err = exporter.Export(context.Background(), testRemoteWriteMetrics(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]testRemoteWriteSeries)
+	for _, s := range receiver.series {
+		if s.timestamp != now.UnixMilli() {
+			t.Errorf("%v timestamp %d, want %d", s.labels, s.timestamp, now.UnixMilli())
+		}
+
+		got[s.labels["__name__"]+"{"+s.labels["le"]+"}"] = s
+	}
+
+	scopeLabels := map[string]string{"job": "payments", "instance": "i-1", "otel_scope_name": "shop"}
+	with := func(name string, labels map[string]string) map[string]string {
+		merged := map[string]string{"__name__": name}
+		for k, v := range scopeLabels {
+			merged[k] = v
+		}
+
+		for k, v := range labels {
+			merged[k] = v
+		}
+
+		return merged
+	}
+
+	want := map[string]testRemoteWriteSeries{
+		"http_requests_total{}":        {labels: with("http_requests_total", map[string]string{"exported_job": "batch", "http_method": "GET"}), value: 7},
+		"latency_seconds_bucket{0.1}":  {labels: with("latency_seconds_bucket", map[string]string{"exported_le": "x", "le": "0.1"}), value: 1},
+		"latency_seconds_bucket{1}":    {labels: with("latency_seconds_bucket", map[string]string{"exported_le": "x", "le": "1"}), value: 3},
+		"latency_seconds_bucket{+Inf}": {labels: with("latency_seconds_bucket", map[string]string{"exported_le": "x", "le": "+Inf"}), value: 6},
+		"latency_seconds_sum{}":        {labels: with("latency_seconds_sum", map[string]string{"exported_le": "x"}), value: 9.5},
+		"latency_seconds_count{}":      {labels: with("latency_seconds_count", map[string]string{"exported_le": "x"}), value: 6},
+		"target_info{}":                {labels: map[string]string{"__name__": "target_info", "job": "payments", "instance": "i-1", "host_name": "node-1"}, value: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("got %d series, want %d", len(got), len(want))
+	}
+
+	for key, w := range want {
+		g, ok := got[key]
+		if !ok {
+			t.Errorf("series %s is not sent", key)
+			continue
+		}
+
+		if !reflect.DeepEqual(g.labels, w.labels) || g.value != w.value {
+			t.Errorf("series %s = %v %v, want %v %v", key, g.labels, g.value, w.labels, w.value)
+		}
+	}
+
+	header := receiver.requests[0].Header
+	if header.Get("Content-Encoding") != "snappy" || header.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("unexpected header %v", header)
+	}
+}
+
+func TestRemoteWriteExporterRetryAfter(t *testing.T) {
+	receiver := &testRemoteWriteReceiver{status: []int{http.StatusTooManyRequests}, retryAfter: "1"}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	exporter, err := NewRemoteWriteExporter(RemoteWriteExporterOption{Endpoint: server.URL, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	err = exporter.Export(context.Background(), testRemoteWriteMetrics(start))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want Retry-After 1s", elapsed)
+	}
+
+	if len(receiver.requests) != 2 || len(receiver.series) == 0 {
+		t.Errorf("got %d requests and %d series, want retry to succeed", len(receiver.requests), len(receiver.series))
+	}
+}
+
+func TestRemoteWriteExporterBadRequest(t *testing.T) {
+	receiver := &testRemoteWriteReceiver{status: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	exporter, err := NewRemoteWriteExporter(RemoteWriteExporterOption{Endpoint: server.URL, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = exporter.Export(context.Background(), testRemoteWriteMetrics(time.Now()))
+	if !errors.Is(err, ErrRemoteWriteFailed) {
+		t.Errorf("got error %v, want %v", err, ErrRemoteWriteFailed)
+	}
+
+	if len(receiver.requests) != 1 {
+		t.Errorf("got %d requests, 400 must not be retried", len(receiver.requests))
+	}
+}
+
+func TestRemoteWriteExporterAuth(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  RemoteWriteExporterOption
+		want string
+	}{
+		{name: "basic", opt: RemoteWriteExporterOption{Username: "user", Password: "secret"}, want: "Basic dXNlcjpzZWNyZXQ="},
+		{name: "bearer", opt: RemoteWriteExporterOption{BearerToken: "token"}, want: "Bearer token"},
+		{name: "none", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &testRemoteWriteReceiver{}
+			server := httptest.NewServer(receiver)
+			t.Cleanup(server.Close)
+
+			tt.opt.Endpoint = server.URL
+			tt.opt.Headers = map[string]string{"X-Scope-OrgID": "tenant"}
+
+			exporter, err := NewRemoteWriteExporter(tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = exporter.Export(context.Background(), testRemoteWriteMetrics(time.Now()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			header := receiver.requests[0].Header
+			if got := header.Get("Authorization"); got != tt.want {
+				t.Errorf("authorization %q, want %q", got, tt.want)
+			}
+
+			if got := header.Get("X-Scope-OrgID"); got != "tenant" {
+				t.Errorf("X-Scope-OrgID %q, want tenant", got)
+			}
+		})
+	}
+
+	_, err := NewRemoteWriteExporter(RemoteWriteExporterOption{Username: "user", BearerToken: "token"})
+	if !errors.Is(err, ErrInvalidRemoteWriteAuth) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidRemoteWriteAuth)
+	}
+}
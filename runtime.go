@@ -0,0 +1,328 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// go runtime metric name from runtime/metrics
+const (
+	runtimeMemoryTotal       = "/memory/classes/total:bytes"
+	runtimeMemoryReleased    = "/memory/classes/heap/released:bytes"
+	runtimeMemoryHeapStacks  = "/memory/classes/heap/stacks:bytes"
+	runtimeMemoryOSStacks    = "/memory/classes/os-stacks:bytes"
+	runtimeMemoryLimit       = "/gc/gomemlimit:bytes"
+	runtimeHeapAllocsBytes   = "/gc/heap/allocs:bytes"
+	runtimeHeapAllocsObjects = "/gc/heap/allocs:objects"
+	runtimeHeapGoal          = "/gc/heap/goal:bytes"
+	runtimeGoroutines        = "/sched/goroutines:goroutines"
+	runtimeGOMAXPROCS        = "/sched/gomaxprocs:threads"
+	runtimeGOGC              = "/gc/gogc:percent"
+	runtimeCgoCalls          = "/cgo/go-to-c-calls:calls"
+	runtimeSchedLatencies    = "/sched/latencies:seconds"
+	runtimeGCPauses          = "/sched/pauses/total/gc:seconds"
+	// runtimeGCPausesDeprecated used when runtimeGCPauses is not supported by the go version
+	runtimeGCPausesDeprecated = "/gc/pauses:seconds"
+)
+
+// go runtime semantic convention attribute
+const (
+	runtimeMemoryTypeKey   = attribute.Key("go.memory.type")
+	runtimeMemoryTypeStack = "stack"
+	runtimeMemoryTypeOther = "other"
+)
+
+// runtime metrics instrumentation scope, the producer scope must be different from the meter scope
+// otherwise prometheus exporter report duplicated scope info
+const (
+	runtimeInstrumentationName         = instrumentationName + "/runtime"
+	runtimeProducerInstrumentationName = runtimeInstrumentationName + "/producer"
+)
+
+// runtimeStartTime start time of the cumulative runtime histogram
+var runtimeStartTime = time.Now()
+
+var _ sdkmetric.Producer = (*RuntimeMetrics)(nil)
+
+// RuntimeMetrics collect go runtime metrics from runtime/metrics using semantic convention name.
+// memory, goroutine, gomaxprocs, gogc and cgo calls (go.cgo.calls, no semantic convention yet) are observed
+// through the meter provider, scheduler latency (go.schedule.duration) and gc pause (go.gc.pause.duration)
+// histogram can't be recorded through the metric api so they are produced to the metric reader
+// as sdkmetric.Producer with the runtime bucket boundaries
+type RuntimeMetrics struct {
+	mu           sync.Mutex
+	samples      []metrics.Sample
+	index        map[string]int
+	registration metric.Registration
+	stopped      bool
+}
+
+// NewRuntimeMetrics create go runtime metrics, register it as producer of the metric reader
+// and start it on the meter provider, for example
+//
+//	runtimeMetrics := otel.NewRuntimeMetrics()
+//	reader := sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithProducer(runtimeMetrics))
+//	provider, _ := otel.NewMetricProvider(res, reader)
+//	err := runtimeMetrics.Start(provider)
+func NewRuntimeMetrics() *RuntimeMetrics {
+	supported := make(map[string]bool)
+	for _, desc := range metrics.All() {
+		supported[desc.Name] = true
+	}
+
+	gcPauses := runtimeGCPauses
+	if !supported[gcPauses] {
+		gcPauses = runtimeGCPausesDeprecated
+	}
+
+	r := &RuntimeMetrics{index: make(map[string]int)}
+
+	for _, name := range []string{
+		runtimeMemoryTotal, runtimeMemoryReleased, runtimeMemoryHeapStacks, runtimeMemoryOSStacks,
+		runtimeMemoryLimit, runtimeHeapAllocsBytes, runtimeHeapAllocsObjects, runtimeHeapGoal,
+		runtimeGoroutines, runtimeGOMAXPROCS, runtimeGOGC, runtimeCgoCalls, runtimeSchedLatencies, gcPauses,
+	} {
+		if !supported[name] {
+			continue
+		}
+
+		r.index[name] = len(r.samples)
+		r.samples = append(r.samples, metrics.Sample{Name: name})
+	}
+
+	// gc pause is looked up by the new name regardless of the go version
+	if i, ok := r.index[runtimeGCPausesDeprecated]; ok {
+		r.index[runtimeGCPauses] = i
+	}
+
+	return r
+}
+
+// Start register the runtime metric instruments on the meter provider
+func (r *RuntimeMetrics) Start(provider metric.MeterProvider) error {
+	meter := provider.Meter(runtimeInstrumentationName)
+
+	memoryUsed, err := meter.Int64ObservableUpDownCounter("go.memory.used",
+		metric.WithDescription("Memory used by the Go runtime."), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	memoryLimit, err := meter.Int64ObservableUpDownCounter("go.memory.limit",
+		metric.WithDescription("Go runtime memory limit configured by the user, if a limit exists."), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	memoryAllocated, err := meter.Int64ObservableCounter("go.memory.allocated",
+		metric.WithDescription("Memory allocated to the heap by the application."), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	memoryAllocations, err := meter.Int64ObservableCounter("go.memory.allocations",
+		metric.WithDescription("Count of allocations to the heap by the application."), metric.WithUnit("{allocation}"))
+	if err != nil {
+		return err
+	}
+
+	memoryGCGoal, err := meter.Int64ObservableUpDownCounter("go.memory.gc.goal",
+		metric.WithDescription("Heap size target for the end of the GC cycle."), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	goroutineCount, err := meter.Int64ObservableUpDownCounter("go.goroutine.count",
+		metric.WithDescription("Count of live goroutines."), metric.WithUnit("{goroutine}"))
+	if err != nil {
+		return err
+	}
+
+	processorLimit, err := meter.Int64ObservableUpDownCounter("go.processor.limit",
+		metric.WithDescription("The number of OS threads that can execute user-level Go code simultaneously."), metric.WithUnit("{thread}"))
+	if err != nil {
+		return err
+	}
+
+	configGOGC, err := meter.Int64ObservableUpDownCounter("go.config.gogc",
+		metric.WithDescription("Heap size target percentage configured by the user, otherwise 100."), metric.WithUnit("%"))
+	if err != nil {
+		return err
+	}
+
+	cgoCalls, err := meter.Int64ObservableCounter("go.cgo.calls",
+		metric.WithDescription("Count of calls made from Go to C by the current process."), metric.WithUnit("{call}"))
+	if err != nil {
+		return err
+	}
+
+	stackAttr := metric.WithAttributes(runtimeMemoryTypeKey.String(runtimeMemoryTypeStack))
+	otherAttr := metric.WithAttributes(runtimeMemoryTypeKey.String(runtimeMemoryTypeOther))
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		metrics.Read(r.samples)
+
+		stack := r.uint64(runtimeMemoryHeapStacks) + r.uint64(runtimeMemoryOSStacks)
+		o.ObserveInt64(memoryUsed, r.uint64(runtimeMemoryTotal)-r.uint64(runtimeMemoryReleased)-stack, otherAttr)
+		o.ObserveInt64(memoryUsed, stack, stackAttr)
+
+		// math.MaxInt64 mean no memory limit
+		if limit := r.uint64(runtimeMemoryLimit); limit != math.MaxInt64 {
+			o.ObserveInt64(memoryLimit, limit)
+		}
+
+		o.ObserveInt64(memoryAllocated, r.uint64(runtimeHeapAllocsBytes))
+		o.ObserveInt64(memoryAllocations, r.uint64(runtimeHeapAllocsObjects))
+		o.ObserveInt64(memoryGCGoal, r.uint64(runtimeHeapGoal))
+		o.ObserveInt64(goroutineCount, r.uint64(runtimeGoroutines))
+		o.ObserveInt64(processorLimit, r.uint64(runtimeGOMAXPROCS))
+		o.ObserveInt64(configGOGC, r.uint64(runtimeGOGC))
+		o.ObserveInt64(cgoCalls, r.uint64(runtimeCgoCalls))
+
+		return nil
+	}, memoryUsed, memoryLimit, memoryAllocated, memoryAllocations, memoryGCGoal,
+		goroutineCount, processorLimit, configGOGC, cgoCalls)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.registration != nil {
+		return errors.Join(registration.Unregister(), errors.New("runtime metrics already started"))
+	}
+
+	r.registration = registration
+	r.stopped = false
+
+	return nil
+}
+
+// Produce returns scheduler latency and gc pause histogram
+func (r *RuntimeMetrics) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return nil, nil
+	}
+
+	metrics.Read(r.samples)
+
+	now := time.Now()
+
+	var data []metricdata.Metrics
+
+	for _, histogram := range []struct {
+		name, description, sample string
+	}{
+		{name: "go.schedule.duration", description: "The time goroutines have spent in the scheduler in a runnable state before actually running.", sample: runtimeSchedLatencies},
+		{name: "go.gc.pause.duration", description: "The time the Go runtime has spent in stop-the-world pauses for garbage collection.", sample: runtimeGCPauses},
+	} {
+		i, ok := r.index[histogram.sample]
+		if !ok || r.samples[i].Value.Kind() != metrics.KindFloat64Histogram {
+			continue
+		}
+
+		data = append(data, metricdata.Metrics{
+			Name:        histogram.name,
+			Description: histogram.description,
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{
+					newRuntimeHistogramDataPoint(r.samples[i].Value.Float64Histogram(), runtimeStartTime, now),
+				},
+			},
+		})
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: runtimeProducerInstrumentationName},
+		Metrics: data,
+	}}, nil
+}
+
+// Stop unregister the runtime metric instruments and stop producing histogram
+func (r *RuntimeMetrics) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopped = true
+
+	if r.registration == nil {
+		return nil
+	}
+
+	err := r.registration.Unregister()
+	r.registration = nil
+
+	return err
+}
+
+// uint64 returns the sample value as int64, zero when the metric is not supported
+func (r *RuntimeMetrics) uint64(name string) int64 {
+	i, ok := r.index[name]
+	if !ok || r.samples[i].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+
+	value := r.samples[i].Value.Uint64()
+	if value > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return int64(value)
+}
+
+// newRuntimeHistogramDataPoint convert runtime histogram keeping the runtime bucket boundaries,
+// the first and last boundary is dropped since the first and last bucket of the exported histogram is open.
+// the runtime doesn't record the sum so it is estimated from the bucket midpoint, or the finite boundary of open bucket
+func newRuntimeHistogramDataPoint(histogram *metrics.Float64Histogram, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	// the histogram memory is reused on the next metrics.Read
+	point := metricdata.HistogramDataPoint[float64]{
+		StartTime:    start,
+		Time:         now,
+		Bounds:       append([]float64(nil), histogram.Buckets[1:len(histogram.Buckets)-1]...),
+		BucketCounts: append([]uint64(nil), histogram.Counts...),
+	}
+
+	for i, count := range histogram.Counts {
+		if count == 0 {
+			continue
+		}
+
+		lower, upper := histogram.Buckets[i], histogram.Buckets[i+1]
+
+		value := (lower + upper) / 2
+		switch {
+		case math.IsInf(lower, -1):
+			value = upper
+		case math.IsInf(upper, 1):
+			value = lower
+		}
+
+		point.Count += count
+		point.Sum += value * float64(count)
+	}
+
+	return point
+}
@@ -0,0 +1,104 @@
+package otel
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewRuntimeHistogramDataPoint(t *testing.T) {
+	histogram := &metrics.Float64Histogram{
+		Counts:  []uint64{1, 0, 2, 3},
+		Buckets: []float64{math.Inf(-1), 0.001, 0.002, 0.004, math.Inf(1)},
+	}
+
+	point := newRuntimeHistogramDataPoint(histogram, time.Unix(1, 0), time.Unix(2, 0))
+
+	if want := []float64{0.001, 0.002, 0.004}; !slices.Equal(point.Bounds, want) {
+		t.Errorf("got bounds %v, want %v", point.Bounds, want)
+	}
+
+	if want := []uint64{1, 0, 2, 3}; !slices.Equal(point.BucketCounts, want) {
+		t.Errorf("got bucket counts %v, want %v", point.BucketCounts, want)
+	}
+
+	// open bucket use the finite boundary, other bucket use the midpoint
+	if want := 0.001 + 2*0.003 + 3*0.004; point.Count != 6 || math.Abs(point.Sum-want) > 1e-12 {
+		t.Errorf("got count %d sum %v, want 6 %v", point.Count, point.Sum, want)
+	}
+
+	// the runtime reuse the histogram memory on the next read
+	histogram.Counts[0] = 10
+	histogram.Buckets[1] = 1
+
+	if point.BucketCounts[0] != 1 || point.Bounds[0] != 0.001 {
+		t.Errorf("data point share the runtime histogram memory")
+	}
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	runtimeMetrics := NewRuntimeMetrics()
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(runtimeMetrics))
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	if err := runtimeMetrics.Start(provider); err != nil {
+		t.Fatal(err)
+	}
+
+	// gc pause histogram is only recorded after gc
+	runtime.GC()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]metricdata.Metrics)
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if !strings.HasPrefix(m.Name, "go.") {
+				t.Errorf("metric %s is not go semantic convention name", m.Name)
+			}
+
+			names[m.Name] = m
+		}
+	}
+
+	for _, name := range []string{"go.memory.used", "go.memory.allocated", "go.memory.allocations", "go.memory.gc.goal",
+		"go.goroutine.count", "go.processor.limit", "go.config.gogc", "go.cgo.calls", "go.schedule.duration", "go.gc.pause.duration"} {
+		if _, ok := names[name]; !ok {
+			t.Errorf("metric %s is not collected", name)
+		}
+	}
+
+	for _, name := range []string{"go.schedule.duration", "go.gc.pause.duration"} {
+		histogram, _ := names[name].Data.(metricdata.Histogram[float64])
+		for _, point := range histogram.DataPoints {
+			if len(point.BucketCounts) != len(point.Bounds)+1 {
+				t.Errorf("%s got %d bucket counts for %d bounds", name, len(point.BucketCounts), len(point.Bounds))
+			}
+		}
+	}
+
+	gcPause, _ := names["go.gc.pause.duration"].Data.(metricdata.Histogram[float64])
+	if len(gcPause.DataPoints) != 1 || gcPause.DataPoints[0].Count == 0 {
+		t.Errorf("got gc pause %+v after gc, want recorded pause", gcPause.DataPoints)
+	}
+
+	if err := runtimeMetrics.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if scopeMetrics, _ := runtimeMetrics.Produce(context.Background()); scopeMetrics != nil {
+		t.Errorf("stopped runtime metrics produce %v", scopeMetrics)
+	}
+}
@@ -0,0 +1,187 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// default slog handler field name
+const (
+	slogTraceIDKeyDefault    = "trace_id"
+	slogSpanIDKeyDefault     = "span_id"
+	slogTraceFlagsKeyDefault = "trace_flags"
+	slogSpanEventName        = logSpanEventName
+)
+
+// SlogHandlerOption option for slog handler
+type SlogHandlerOption struct {
+	// TraceIDKey field name for trace id (default: trace_id)
+	TraceIDKey string
+	// SpanIDKey field name for span id (default: span_id)
+	SpanIDKey string
+	// TraceFlagsKey field name for trace flags (default: trace_flags)
+	TraceFlagsKey string
+	// SpanEvents add warn and error record as event of the span from the context
+	SpanEvents bool
+}
+
+// SlogHandler slog handler wrapper that inject trace id, span id and trace flags from the context span
+type SlogHandler struct {
+	handler slog.Handler
+	opt     SlogHandlerOption
+
+	// root wrapped handler without attrs and groups, with scopes to replay WithAttrs and WithGroup on it,
+	// so the trace fields is added at top level when the handler has group
+	root   slog.Handler
+	scopes []func(slog.Handler) slog.Handler
+
+	// attrs and groups used for span event attributes
+	attrs  []attribute.KeyValue
+	groups string
+}
+
+// NewSlogHandler wrap existing slog handler, for example
+//
+//	logger := slog.New(otel.NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil), otel.SlogHandlerOption{}))
+//	logger.InfoContext(ctx, "hello")
+//
+// the trace fields only added when the context passed to the logger has valid span context
+func NewSlogHandler(handler slog.Handler, opt SlogHandlerOption) *SlogHandler {
+	if opt.TraceIDKey == "" {
+		opt.TraceIDKey = slogTraceIDKeyDefault
+	}
+
+	if opt.SpanIDKey == "" {
+		opt.SpanIDKey = slogSpanIDKeyDefault
+	}
+
+	if opt.TraceFlagsKey == "" {
+		opt.TraceFlagsKey = slogTraceFlagsKeyDefault
+	}
+
+	return &SlogHandler{
+		handler: handler,
+		opt:     opt,
+		root:    handler,
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle add trace fields to the record and pass it to the wrapped handler,
+// the trace fields is at top level even when the handler has group
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	span := trace.SpanFromContext(ctx)
+	spanContext := span.SpanContext()
+
+	if h.opt.SpanEvents && record.Level >= slog.LevelWarn && span.IsRecording() {
+		h.addSpanEvent(span, record)
+	}
+
+	if !spanContext.IsValid() {
+		return h.handler.Handle(ctx, record)
+	}
+
+	traceAttrs := []slog.Attr{
+		slog.String(h.opt.TraceIDKey, spanContext.TraceID().String()),
+		slog.String(h.opt.SpanIDKey, spanContext.SpanID().String()),
+		slog.String(h.opt.TraceFlagsKey, spanContext.TraceFlags().String()),
+	}
+
+	if h.groups == "" {
+		record = record.Clone()
+		record.AddAttrs(traceAttrs...)
+
+		return h.handler.Handle(ctx, record)
+	}
+
+	// record attribute is added to the last group, add the trace fields before the group is opened
+	handler := h.root.WithAttrs(traceAttrs)
+	for _, scope := range h.scopes {
+		handler = scope(handler)
+	}
+
+	return handler.Handle(ctx, record)
+}
+
+// WithAttrs returns new handler with the attributes
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.handler = h.handler.WithAttrs(attrs)
+	clone.scopes = append(clone.scopes[:len(clone.scopes):len(clone.scopes)], func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+	clone.attrs = append(clone.attrs[:len(clone.attrs):len(clone.attrs)], slogAttrsToKeyValues(h.groups, attrs)...)
+
+	return &clone
+}
+
+// WithGroup returns new handler with the group
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.handler = h.handler.WithGroup(name)
+	clone.scopes = append(clone.scopes[:len(clone.scopes):len(clone.scopes)], func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+	clone.groups = h.groups + name + "."
+
+	return &clone
+}
+
+func (h *SlogHandler) addSpanEvent(span trace.Span, record slog.Record) {
+	attrs := make([]attribute.KeyValue, 0, len(h.attrs)+record.NumAttrs()+2)
+	attrs = append(attrs,
+		attribute.String(logSpanEventSeverityKey, record.Level.String()),
+		attribute.String(logSpanEventMessageKey, record.Message),
+	)
+	attrs = append(attrs, h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, slogAttrsToKeyValues(h.groups, []slog.Attr{attr})...)
+		return true
+	})
+
+	span.AddEvent(slogSpanEventName, trace.WithTimestamp(record.Time), trace.WithAttributes(attrs...))
+}
+
+// slogAttrsToKeyValues convert slog attributes to open telemetry attributes, group is flatten with dot separator
+func slogAttrsToKeyValues(prefix string, attrs []slog.Attr) []attribute.KeyValue {
+	keyValues := make([]attribute.KeyValue, 0, len(attrs))
+
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+		key := prefix + attr.Key
+
+		switch value.Kind() {
+		case slog.KindGroup:
+			groupPrefix := prefix
+			if attr.Key != "" {
+				groupPrefix = key + "."
+			}
+
+			keyValues = append(keyValues, slogAttrsToKeyValues(groupPrefix, value.Group())...)
+		case slog.KindBool:
+			keyValues = append(keyValues, attribute.Bool(key, value.Bool()))
+		case slog.KindInt64:
+			keyValues = append(keyValues, attribute.Int64(key, value.Int64()))
+		case slog.KindUint64:
+			keyValues = append(keyValues, attribute.Int64(key, int64(value.Uint64())))
+		case slog.KindFloat64:
+			keyValues = append(keyValues, attribute.Float64(key, value.Float64()))
+		default:
+			keyValues = append(keyValues, attribute.String(key, value.String()))
+		}
+	}
+
+	return keyValues
+}
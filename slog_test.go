@@ -0,0 +1,78 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSlogHandlerGroup(t *testing.T) {
+	var buf bytes.Buffer
+
+	ctx := testSpanContext(true)
+	handler := NewSlogHandler(slog.NewJSONHandler(&buf, nil), SlogHandlerOption{})
+	logger := slog.New(handler).With("service", "payments").WithGroup("req").With("method", "GET")
+
+	logger.InfoContext(ctx, "hello", "path", "/pay")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got["trace_id"] != "0102030405060708090a0b0c0d0e0f10" || got["span_id"] != "0102030405060708" || got["trace_flags"] != "01" {
+		t.Errorf("trace fields is not at top level: %s", buf.String())
+	}
+
+	if got["service"] != "payments" {
+		t.Errorf("attribute before group is lost: %s", buf.String())
+	}
+
+	req, _ := got["req"].(map[string]any)
+	if req["method"] != "GET" || req["path"] != "/pay" || req["trace_id"] != nil {
+		t.Errorf("unexpected req group: %s", buf.String())
+	}
+}
+
+func TestSlogHandlerSpanEvent(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		recorder = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	)
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "pay")
+
+	handler := NewSlogHandler(slog.NewJSONHandler(&buf, nil), SlogHandlerOption{SpanEvents: true})
+	logger := slog.New(handler).WithGroup("req")
+
+	logger.InfoContext(ctx, "ignored")
+	logger.WarnContext(ctx, "slow", "ms", 1200)
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want warn record only", len(events))
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range events[0].Attributes {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	want := map[string]string{logSpanEventSeverityKey: "WARN", logSpanEventMessageKey: "slow", "req.ms": "1200"}
+	if len(attrs) != len(want) {
+		t.Errorf("got event attributes %v, want %v", attrs, want)
+	}
+
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("event attribute %s = %q, want %q", key, attrs[key], value)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package otel
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// metricSnapshotMetric rendered metric of the snapshot
+type metricSnapshotMetric struct {
+	scope  string
+	name   string
+	unit   string
+	header string
+	points []metricSnapshotPoint
+}
+
+// metricSnapshotPoint rendered data point of the snapshot
+type metricSnapshotPoint struct {
+	attrs string
+	value string
+}
+
+// FormatMetricSnapshot render the snapshot as deterministic text for golden file test,
+// scope, metric and data point is sorted and resource, timestamp and exemplar is omitted
+//
+//	scope github.com/erry-az/otel-go
+//	  metric http.server.requests {request} sum int64 monotonic cumulative
+//	    {http.method=GET} 3
+func FormatMetricSnapshot(rm *metricdata.ResourceMetrics) string {
+	var (
+		b     strings.Builder
+		scope string
+	)
+
+	for i, m := range newMetricSnapshot(rm) {
+		if i == 0 || m.scope != scope {
+			scope = m.scope
+			b.WriteString("scope " + scope + "\n")
+		}
+
+		b.WriteString("  metric " + m.name + " " + m.header + "\n")
+
+		for _, p := range m.points {
+			b.WriteString("    " + p.attrs + " " + p.value + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// DiffMetricSnapshots compare two snapshot by scope, metric, unit and attribute set, returns empty string when equal.
+// every line is "- " for data point only in before, "+ " for data point only in after
+// and "~ " for changed metric type or data point value
+//
+//	if diff := otel.DiffMetricSnapshots(want, got); diff != "" {
+//		t.Errorf("metric mismatch:\n%s", diff)
+//	}
+func DiffMetricSnapshots(before, after *metricdata.ResourceMetrics) string {
+	var (
+		lines         []string
+		beforeMetrics = metricSnapshotIndex(newMetricSnapshot(before))
+		afterMetrics  = metricSnapshotIndex(newMetricSnapshot(after))
+	)
+
+	for key, b := range beforeMetrics {
+		a, ok := afterMetrics[key]
+		if !ok {
+			for _, p := range b.points {
+				lines = append(lines, "- "+key+" "+p.attrs+" "+p.value)
+			}
+
+			continue
+		}
+
+		if a.header != b.header {
+			lines = append(lines, "~ "+key+" "+b.header+" -> "+a.header)
+		}
+
+		afterPoints := make(map[string]string, len(a.points))
+		for _, p := range a.points {
+			afterPoints[p.attrs] = p.value
+		}
+
+		for _, p := range b.points {
+			value, ok := afterPoints[p.attrs]
+			delete(afterPoints, p.attrs)
+
+			switch {
+			case !ok:
+				lines = append(lines, "- "+key+" "+p.attrs+" "+p.value)
+			case value != p.value:
+				lines = append(lines, "~ "+key+" "+p.attrs+" "+p.value+" -> "+value)
+			}
+		}
+
+		for attrs, value := range afterPoints {
+			lines = append(lines, "+ "+key+" "+attrs+" "+value)
+		}
+	}
+
+	for key, a := range afterMetrics {
+		if _, ok := beforeMetrics[key]; ok {
+			continue
+		}
+
+		for _, p := range a.points {
+			lines = append(lines, "+ "+key+" "+p.attrs+" "+p.value)
+		}
+	}
+
+	// sort by the key after the change marker so the change of same data point is grouped
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i][2:] == lines[j][2:] {
+			return lines[i] < lines[j]
+		}
+
+		return lines[i][2:] < lines[j][2:]
+	})
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// metricSnapshotIndex index the metric by "scope metric unit",
+// duplicate metric of the same key is suffixed with "#2", "#3" and so on instead of replacing the previous metric
+func metricSnapshotIndex(metrics []metricSnapshotMetric) map[string]metricSnapshotMetric {
+	index := make(map[string]metricSnapshotMetric, len(metrics))
+
+	for _, m := range metrics {
+		key := m.scope + " " + m.name
+		if m.unit != "" {
+			key += " " + m.unit
+		}
+
+		duplicateKey := key
+		for i := 2; ; i++ {
+			if _, ok := index[duplicateKey]; !ok {
+				break
+			}
+
+			duplicateKey = key + " #" + strconv.Itoa(i)
+		}
+
+		index[duplicateKey] = m
+	}
+
+	return index
+}
+
+// newMetricSnapshot render the metric sorted by scope and name, the data point is sorted by attribute
+func newMetricSnapshot(rm *metricdata.ResourceMetrics) []metricSnapshotMetric {
+	if rm == nil {
+		return nil
+	}
+
+	var metrics []metricSnapshotMetric
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		scope := scopeMetrics.Scope.Name
+		if scopeMetrics.Scope.Version != "" {
+			scope += "@" + scopeMetrics.Scope.Version
+		}
+
+		for _, m := range scopeMetrics.Metrics {
+			header, points := metricSnapshotData(m.Data)
+			if m.Unit != "" {
+				header = m.Unit + " " + header
+			}
+
+			sort.Slice(points, func(i, j int) bool {
+				return points[i].attrs < points[j].attrs
+			})
+
+			metrics = append(metrics, metricSnapshotMetric{scope: scope, name: m.Name, unit: m.Unit, header: header, points: points})
+		}
+	}
+
+	sort.SliceStable(metrics, func(i, j int) bool {
+		if metrics[i].scope != metrics[j].scope {
+			return metrics[i].scope < metrics[j].scope
+		}
+
+		return metrics[i].name < metrics[j].name
+	})
+
+	return metrics
+}
+
+// metricSnapshotData returns the aggregation header and rendered data point
+func metricSnapshotData(data metricdata.Aggregation) (string, []metricSnapshotPoint) {
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		return metricSnapshotSum(d, "int64")
+	case metricdata.Sum[float64]:
+		return metricSnapshotSum(d, "float64")
+	case metricdata.Gauge[int64]:
+		return "gauge int64", metricSnapshotPoints(d.DataPoints)
+	case metricdata.Gauge[float64]:
+		return "gauge float64", metricSnapshotPoints(d.DataPoints)
+	case metricdata.Histogram[int64]:
+		return "histogram int64 " + metricSnapshotTemporality(d.Temporality), metricSnapshotHistogramPoints(d.DataPoints)
+	case metricdata.Histogram[float64]:
+		return "histogram float64 " + metricSnapshotTemporality(d.Temporality), metricSnapshotHistogramPoints(d.DataPoints)
+	case metricdata.ExponentialHistogram[int64]:
+		return "exponential_histogram int64 " + metricSnapshotTemporality(d.Temporality), metricSnapshotExponentialHistogramPoints(d.DataPoints)
+	case metricdata.ExponentialHistogram[float64]:
+		return "exponential_histogram float64 " + metricSnapshotTemporality(d.Temporality), metricSnapshotExponentialHistogramPoints(d.DataPoints)
+	case metricdata.Summary:
+		points := make([]metricSnapshotPoint, 0, len(d.DataPoints))
+
+		for _, p := range d.DataPoints {
+			quantiles := make([]string, 0, len(p.QuantileValues))
+			for _, q := range p.QuantileValues {
+				quantiles = append(quantiles, formatSnapshotFloat(q.Quantile)+":"+formatSnapshotFloat(q.Value))
+			}
+
+			points = append(points, metricSnapshotPoint{
+				attrs: formatSnapshotAttributes(p.Attributes),
+				value: fmt.Sprintf("count=%d sum=%s quantiles=[%s]", p.Count, formatSnapshotFloat(p.Sum), strings.Join(quantiles, " ")),
+			})
+		}
+
+		return "summary", points
+	}
+
+	return fmt.Sprintf("unknown %T", data), nil
+}
+
+func metricSnapshotSum[N int64 | float64](d metricdata.Sum[N], numberType string) (string, []metricSnapshotPoint) {
+	header := "sum " + numberType
+	if d.IsMonotonic {
+		header += " monotonic"
+	}
+
+	return header + " " + metricSnapshotTemporality(d.Temporality), metricSnapshotPoints(d.DataPoints)
+}
+
+func metricSnapshotPoints[N int64 | float64](dataPoints []metricdata.DataPoint[N]) []metricSnapshotPoint {
+	points := make([]metricSnapshotPoint, 0, len(dataPoints))
+
+	for _, p := range dataPoints {
+		points = append(points, metricSnapshotPoint{
+			attrs: formatSnapshotAttributes(p.Attributes),
+			value: fmt.Sprint(p.Value),
+		})
+	}
+
+	return points
+}
+
+// metricSnapshotHistogramPoints render "count=1 sum=0.5 min=0.5 max=0.5 buckets=[0.1:0 1:1 +Inf:0]" data point
+func metricSnapshotHistogramPoints[N int64 | float64](dataPoints []metricdata.HistogramDataPoint[N]) []metricSnapshotPoint {
+	points := make([]metricSnapshotPoint, 0, len(dataPoints))
+
+	for _, p := range dataPoints {
+		buckets := make([]string, 0, len(p.BucketCounts))
+
+		for i, count := range p.BucketCounts {
+			le := "+Inf"
+			if i < len(p.Bounds) {
+				le = formatSnapshotFloat(p.Bounds[i])
+			}
+
+			buckets = append(buckets, le+":"+strconv.FormatUint(count, 10))
+		}
+
+		points = append(points, metricSnapshotPoint{
+			attrs: formatSnapshotAttributes(p.Attributes),
+			value: fmt.Sprintf("count=%d sum=%v%s buckets=[%s]", p.Count, p.Sum,
+				formatSnapshotExtrema(p.Min, p.Max), strings.Join(buckets, " ")),
+		})
+	}
+
+	return points
+}
+
+// metricSnapshotExponentialHistogramPoints render "count=1 sum=0.5 min=0.5 max=0.5 scale=20 zero=0 positive=-1:[1] negative=0:[]" data point
+func metricSnapshotExponentialHistogramPoints[N int64 | float64](dataPoints []metricdata.ExponentialHistogramDataPoint[N]) []metricSnapshotPoint {
+	points := make([]metricSnapshotPoint, 0, len(dataPoints))
+
+	for _, p := range dataPoints {
+		points = append(points, metricSnapshotPoint{
+			attrs: formatSnapshotAttributes(p.Attributes),
+			value: fmt.Sprintf("count=%d sum=%v%s scale=%d zero=%d positive=%s negative=%s", p.Count, p.Sum,
+				formatSnapshotExtrema(p.Min, p.Max), p.Scale, p.ZeroCount,
+				formatSnapshotExponentialBucket(p.PositiveBucket), formatSnapshotExponentialBucket(p.NegativeBucket)),
+		})
+	}
+
+	return points
+}
+
+func metricSnapshotTemporality(temporality metricdata.Temporality) string {
+	switch temporality {
+	case metricdata.CumulativeTemporality:
+		return "cumulative"
+	case metricdata.DeltaTemporality:
+		return "delta"
+	}
+
+	return "undefined"
+}
+
+// formatSnapshotAttributes render attribute set sorted by key as "{key=value,...}"
+func formatSnapshotAttributes(attrs attribute.Set) string {
+	return "{" + attrs.Encoded(attribute.DefaultEncoder()) + "}"
+}
+
+func formatSnapshotFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func formatSnapshotExtrema[N int64 | float64](minExtrema, maxExtrema metricdata.Extrema[N]) string {
+	var s string
+
+	if value, ok := minExtrema.Value(); ok {
+		s += fmt.Sprint(" min=", value)
+	}
+
+	if value, ok := maxExtrema.Value(); ok {
+		s += fmt.Sprint(" max=", value)
+	}
+
+	return s
+}
+
+func formatSnapshotExponentialBucket(bucket metricdata.ExponentialBucket) string {
+	counts := make([]string, 0, len(bucket.Counts))
+	for _, count := range bucket.Counts {
+		counts = append(counts, strconv.FormatUint(count, 10))
+	}
+
+	return strconv.Itoa(int(bucket.Offset)) + ":[" + strings.Join(counts, " ") + "]"
+}
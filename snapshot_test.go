@@ -0,0 +1,163 @@
+package otel
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func testSnapshotResourceMetrics(scopeMetrics ...metricdata.ScopeMetrics) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{ScopeMetrics: scopeMetrics}
+}
+
+func testSnapshotCounter(name, unit string, points ...metricdata.DataPoint[int64]) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name: name,
+		Unit: unit,
+		Data: metricdata.Sum[int64]{
+			DataPoints:  points,
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+		},
+	}
+}
+
+func testSnapshotPoint(value int64, attrs ...attribute.KeyValue) metricdata.DataPoint[int64] {
+	return metricdata.DataPoint[int64]{Attributes: attribute.NewSet(attrs...), Value: value}
+}
+
+func TestFormatMetricSnapshot(t *testing.T) {
+	rm := testSnapshotResourceMetrics(
+		metricdata.ScopeMetrics{
+			Scope: instrumentation.Scope{Name: "b", Version: "v1"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "http.server.duration",
+					Unit: "s",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attribute.NewSet(attribute.String("http.method", "GET")),
+							Count:        3,
+							Sum:          1.75,
+							Min:          metricdata.NewExtrema(0.05),
+							Max:          metricdata.NewExtrema(1.5),
+							Bounds:       []float64{0.1, 1},
+							BucketCounts: []uint64{1, 1, 1},
+						}},
+					},
+				},
+				{
+					Name: "http.server.size",
+					Unit: "By",
+					Data: metricdata.ExponentialHistogram[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+							Count:          4,
+							Sum:            10,
+							Scale:          1,
+							ZeroCount:      1,
+							PositiveBucket: metricdata.ExponentialBucket{Offset: 2, Counts: []uint64{1, 2}},
+						}},
+					},
+				},
+			},
+		},
+		metricdata.ScopeMetrics{
+			Scope: instrumentation.Scope{Name: "a"},
+			Metrics: []metricdata.Metrics{
+				{Name: "queue.size", Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{{Value: 1.5}}}},
+				testSnapshotCounter("http.server.requests", "{request}",
+					testSnapshotPoint(2, attribute.String("http.method", "POST")),
+					testSnapshotPoint(3, attribute.String("http.method", "GET"), attribute.Int("http.status_code", 200)),
+				),
+			},
+		},
+	)
+
+	want := `scope a
+  metric http.server.requests {request} sum int64 monotonic cumulative
+    {http.method=GET,http.status_code=200} 3
+    {http.method=POST} 2
+  metric queue.size gauge float64
+    {} 1.5
+scope b@v1
+  metric http.server.duration s histogram float64 delta
+    {http.method=GET} count=3 sum=1.75 min=0.05 max=1.5 buckets=[0.1:1 1:1 +Inf:1]
+  metric http.server.size By exponential_histogram int64 cumulative
+    {} count=4 sum=10 scale=1 zero=1 positive=2:[1 2] negative=0:[]
+`
+
+	if got := FormatMetricSnapshot(rm); got != want {
+		t.Errorf("got snapshot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := FormatMetricSnapshot(nil); got != "" {
+		t.Errorf("got nil snapshot %q, want empty", got)
+	}
+}
+
+func TestDiffMetricSnapshots(t *testing.T) {
+	scope := instrumentation.Scope{Name: "a"}
+	get := attribute.String("http.method", "GET")
+	post := attribute.String("http.method", "POST")
+
+	before := testSnapshotResourceMetrics(metricdata.ScopeMetrics{
+		Scope: scope,
+		Metrics: []metricdata.Metrics{
+			testSnapshotCounter("requests", "{request}", testSnapshotPoint(3, get), testSnapshotPoint(1, post)),
+			testSnapshotCounter("removed", "", testSnapshotPoint(1)),
+			testSnapshotCounter("size", "By", testSnapshotPoint(10)),
+			{Name: "typed", Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{testSnapshotPoint(1)}}},
+		},
+	})
+
+	after := testSnapshotResourceMetrics(metricdata.ScopeMetrics{
+		Scope: scope,
+		Metrics: []metricdata.Metrics{
+			testSnapshotCounter("requests", "{request}", testSnapshotPoint(5, get), testSnapshotPoint(1, attribute.String("http.method", "PUT"))),
+			testSnapshotCounter("added", "", testSnapshotPoint(2)),
+			testSnapshotCounter("size", "KiBy", testSnapshotPoint(10)),
+			testSnapshotCounter("typed", "", testSnapshotPoint(1)),
+		},
+	})
+
+	want := `+ a added {} 2
+- a removed {} 1
+~ a requests {request} {http.method=GET} 3 -> 5
+- a requests {request} {http.method=POST} 1
++ a requests {request} {http.method=PUT} 1
+- a size By {} 10
++ a size KiBy {} 10
+~ a typed gauge int64 -> sum int64 monotonic cumulative
+`
+
+	if got := DiffMetricSnapshots(before, after); got != want {
+		t.Errorf("got diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := DiffMetricSnapshots(before, before); got != "" {
+		t.Errorf("got diff of same snapshot:\n%s", got)
+	}
+}
+
+func TestDiffMetricSnapshotsDuplicate(t *testing.T) {
+	// same name and unit with different instrument kind is kept as separate metric instead of replaced
+	rm := func(gauge int64) *metricdata.ResourceMetrics {
+		return testSnapshotResourceMetrics(metricdata.ScopeMetrics{
+			Scope: instrumentation.Scope{Name: "a"},
+			Metrics: []metricdata.Metrics{
+				testSnapshotCounter("jobs", "", testSnapshotPoint(1)),
+				{Name: "jobs", Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{testSnapshotPoint(gauge)}}},
+			},
+		})
+	}
+
+	want := "~ a jobs #2 {} 1 -> 2\n"
+
+	if got := DiffMetricSnapshots(rm(1), rm(2)); got != want {
+		t.Errorf("got diff:\n%s\nwant:\n%s", got, want)
+	}
+}
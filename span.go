@@ -0,0 +1,85 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName name of tracer used by the span helper
+const instrumentationName = "github.com/erry-az/otel-go"
+
+// SpanEndFunc end the span started by Start, pass pointer of returned error
+// so the span status and exception event will follow the error
+type SpanEndFunc func(errp *error)
+
+// Start starts a new span from the global trace provider and returns the end function
+// the end function must be deferred, for example:
+//
+//	func doSomething(ctx context.Context) (err error) {
+//		ctx, end := otel.Start(ctx, "doSomething", attribute.String("key", "value"))
+//		defer end(&err)
+//		...
+//	}
+//
+// when the error is not nil the span status is set to error and the exception is recorded with stack trace.
+// when a panic happen the panic is recorded to the span, the span is ended and the panic is re-thrown.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, SpanEndFunc) {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+
+	return ctx, newSpanEndFunc(span)
+}
+
+// newSpanEndFunc create end function for the span, see Start
+func newSpanEndFunc(span trace.Span) SpanEndFunc {
+	return func(errp *error) {
+		// recover only work when called directly by the deferred function
+		if r := recover(); r != nil {
+			recordPanic(span, r)
+			span.End()
+			panic(r)
+		}
+
+		if errp != nil {
+			RecordError(span, *errp)
+		}
+
+		span.End()
+	}
+}
+
+// Trace wrap fn within a new span, the span will be ended when fn return
+// the returned error and panic will be recorded same as Start
+func Trace[T any](ctx context.Context, name string, fn func(ctx context.Context) (T, error), attrs ...attribute.KeyValue) (result T, err error) {
+	ctx, end := Start(ctx, name, attrs...)
+	defer end(&err)
+
+	return fn(ctx)
+}
+
+// RecordError record error with stack trace to the span and set the span status as error
+// nil error will be ignored
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err, trace.WithStackTrace(true))
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func recordPanic(span trace.Span, r any) {
+	err, ok := r.(error)
+	if ok {
+		err = fmt.Errorf("%w: %w", ErrPanic, err)
+	} else {
+		err = fmt.Errorf("%w: %v", ErrPanic, r)
+	}
+
+	span.RecordError(err, trace.WithStackTrace(true))
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,6 @@
+package otel
+
+import "errors"
+
+// ErrPanic error recorded to the span when the traced function panic
+var ErrPanic = errors.New("panic")
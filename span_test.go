@@ -0,0 +1,211 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// testSpanException returns the attributes of the exception event of the span
+func testSpanException(t *testing.T, span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	t.Helper()
+
+	for _, event := range span.Events() {
+		if event.Name != semconv.ExceptionEventName {
+			continue
+		}
+
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, attr := range event.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+
+		return attrs
+	}
+
+	t.Fatalf("span %s has no exception event", span.Name())
+
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	errTest := errors.New("test error")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{name: "ok", err: nil, wantStatus: codes.Unset},
+		{name: "error", err: errTest, wantStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := setTestTracerProvider(t)
+
+			func() (err error) {
+				_, end := Start(context.Background(), "doSomething", attribute.String("key", "value"))
+				defer end(&err)
+
+				return tt.err
+			}()
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+
+			span := spans[0]
+
+			if span.Name() != "doSomething" || span.InstrumentationScope().Name != instrumentationName {
+				t.Errorf("got span %q scope %q", span.Name(), span.InstrumentationScope().Name)
+			}
+
+			if got := testSpanAttributes(span)["key"]; got.AsString() != "value" {
+				t.Errorf("got key attribute %q, want value", got.AsString())
+			}
+
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("got status %s, want %s", span.Status().Code, tt.wantStatus)
+			}
+
+			if tt.err == nil {
+				if len(span.Events()) != 0 {
+					t.Errorf("got %d events, want no exception", len(span.Events()))
+				}
+
+				return
+			}
+
+			exception := testSpanException(t, span)
+			if got := exception[semconv.ExceptionMessageKey]; got.AsString() != tt.err.Error() {
+				t.Errorf("got exception message %q, want %q", got.AsString(), tt.err)
+			}
+
+			if got := exception[semconv.ExceptionStacktraceKey]; got.AsString() == "" {
+				t.Error("got empty exception stack trace")
+			}
+		})
+	}
+}
+
+func TestStartPanic(t *testing.T) {
+	errTest := errors.New("test error")
+
+	tests := []struct {
+		name        string
+		panic       any
+		wantMessage string
+	}{
+		{name: "value", panic: "boom", wantMessage: "panic: boom"},
+		{name: "error", panic: errTest, wantMessage: "panic: test error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := setTestTracerProvider(t)
+
+			got := func() (r any) {
+				defer func() { r = recover() }()
+
+				func() (err error) {
+					_, end := Start(context.Background(), "doSomething")
+					defer end(&err)
+
+					panic(tt.panic)
+				}()
+
+				return nil
+			}()
+
+			if got != tt.panic {
+				t.Errorf("got re-panic %v, want %v", got, tt.panic)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want ended span before re-panic", len(spans))
+			}
+
+			span := spans[0]
+
+			if span.Status().Code != codes.Error || span.Status().Description != tt.wantMessage {
+				t.Errorf("got status %s %q, want error %q", span.Status().Code, span.Status().Description, tt.wantMessage)
+			}
+
+			exception := testSpanException(t, spans[0])
+			if got := exception[semconv.ExceptionMessageKey]; got.AsString() != tt.wantMessage {
+				t.Errorf("got exception message %q, want %q", got.AsString(), tt.wantMessage)
+			}
+
+			if got := exception[semconv.ExceptionStacktraceKey]; !strings.Contains(got.AsString(), "panic") {
+				t.Errorf("got exception stack trace %q, want panic stack", got.AsString())
+			}
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	recorder := setTestTracerProvider(t)
+	errTest := errors.New("test error")
+
+	result, err := Trace(context.Background(), "getUser", func(ctx context.Context) (string, error) {
+		_, end := Start(ctx, "child")
+		end(nil)
+
+		return "user", nil
+	}, attribute.String("key", "value"))
+	if err != nil || result != "user" {
+		t.Errorf("got %q, %v, want user result", result, err)
+	}
+
+	result, err = Trace(context.Background(), "getUser", func(context.Context) (string, error) {
+		return "", errTest
+	})
+	if !errors.Is(err, errTest) || result != "" {
+		t.Errorf("got %q, %v, want test error", result, err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	child, ok, failed := spans[0], spans[1], spans[2]
+
+	if child.Parent().SpanID() != ok.SpanContext().SpanID() {
+		t.Errorf("got child parent %s, want traced span %s", child.Parent().SpanID(), ok.SpanContext().SpanID())
+	}
+
+	if ok.Status().Code != codes.Unset || testSpanAttributes(ok)["key"].AsString() != "value" {
+		t.Errorf("got status %s attributes %v, want unset status with key attribute", ok.Status().Code, ok.Attributes())
+	}
+
+	if failed.Status().Code != codes.Error || failed.Status().Description != errTest.Error() {
+		t.Errorf("got status %s %q, want error status", failed.Status().Code, failed.Status().Description)
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	recorder := setTestTracerProvider(t)
+
+	_, end := Start(context.Background(), "doSomething")
+	end(nil)
+
+	var err error
+	_, end = Start(context.Background(), "doSomething")
+	end(&err)
+
+	for _, span := range recorder.Ended() {
+		if span.Status().Code != codes.Unset || len(span.Events()) != 0 {
+			t.Errorf("got status %s with %d events, want nil error ignored", span.Status().Code, len(span.Events()))
+		}
+	}
+}
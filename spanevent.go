@@ -0,0 +1,232 @@
+package otel
+
+import (
+	"context"
+	"time"
+
+	"github.com/erry-az/otel-go/logbridge"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// span event and log record mirroring setting
+const (
+	// logSpanEventName span event name of mirrored log record, the event is not emitted back as log record
+	logSpanEventName        = "log"
+	logSpanEventSeverityKey = "log.severity"
+	logSpanEventMessageKey  = "log.message"
+	spanEventLogNameDefault = instrumentationName + "/spanevent"
+	spanEventExceptionName  = semconv.ExceptionEventName
+)
+
+var (
+	_ sdklog.Processor       = (*logSpanEventProcessor)(nil)
+	_ sdktrace.SpanProcessor = (*SpanEventLogProcessor)(nil)
+)
+
+// spanEventLogContextKey context key of log record emitted from span event, the record is not mirrored back as span event
+type spanEventLogContextKey struct{}
+
+// LogSpanEventOption option for log span event processor
+type LogSpanEventOption struct {
+	// MinSeverity minimum severity mirrored as span event, empty mirror all severity
+	MinSeverity LogSeverity
+}
+
+// logSpanEventProcessor log processor that add the record as event of the recording span from the context
+type logSpanEventProcessor struct {
+	minSeverity log.Severity
+}
+
+// SpanEventLogOption option for span event log processor
+type SpanEventLogOption struct {
+	// LoggerProvider log provider to emit the record, global log provider is used when nil
+	LoggerProvider log.LoggerProvider
+	// Name instrumentation scope name of the logger (default: github.com/erry-az/otel-go/spanevent)
+	Name string
+}
+
+// SpanEventLogProcessor span processor that emit every span event of the ended span as log record
+type SpanEventLogProcessor struct {
+	logger log.Logger
+}
+
+// NewLogSpanEventProcessor create log processor that add every log record as "log" event
+// with log.severity, log.message and the record attributes to the recording span from the emit context,
+// add it next to the exporter processor
+//
+//	processor, err := otel.NewLogSpanEventProcessor(otel.LogSpanEventOption{MinSeverity: otel.WarnLogSeverity})
+//	otel.NewLogProvider(res, exporter, sdklog.WithProcessor(processor))
+func NewLogSpanEventProcessor(opt LogSpanEventOption) (sdklog.Processor, error) {
+	minSeverity, err := opt.MinSeverity.severity()
+	if err != nil {
+		return nil, err
+	}
+
+	return &logSpanEventProcessor{minSeverity: minSeverity}, nil
+}
+
+// OnEmit add the record as event of the span, record emitted from span event is skipped
+func (p *logSpanEventProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if !p.enabled(ctx, record.Severity()) {
+		return nil
+	}
+
+	severityText := record.SeverityText()
+	if severityText == "" {
+		severityText = record.Severity().String()
+	}
+
+	attrs := make([]attribute.KeyValue, 0, record.AttributesLen()+2)
+	attrs = append(attrs,
+		attribute.String(logSpanEventSeverityKey, severityText),
+		attribute.String(logSpanEventMessageKey, record.Body().String()),
+	)
+
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs = append(attrs, logKeyValueToAttributes(kv.Key, kv.Value)...)
+		return true
+	})
+
+	timestamp := record.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = record.ObservedTimestamp()
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(logSpanEventName, trace.WithTimestamp(timestamp), trace.WithAttributes(attrs...))
+
+	return nil
+}
+
+// Enabled returns true when the context has recording span and the severity is not below the minimum severity
+func (p *logSpanEventProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	severity, _ := param.Severity()
+
+	return p.enabled(ctx, severity)
+}
+
+// Shutdown do nothing
+func (p *logSpanEventProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush do nothing
+func (p *logSpanEventProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (p *logSpanEventProcessor) enabled(ctx context.Context, severity log.Severity) bool {
+	if ctx.Value(spanEventLogContextKey{}) != nil || !trace.SpanFromContext(ctx).IsRecording() {
+		return false
+	}
+
+	return severity == log.SeverityUndefined || severity >= p.minSeverity
+}
+
+// NewSpanEventLogProcessor create span processor that emit every span event as log record with the span context
+// when the span end, exception event is emitted as error record with exception message as body,
+// "log" event mirrored from log record is skipped
+//
+//	otel.NewTraceProvider(res, exporter, sdktrace.WithSpanProcessor(otel.NewSpanEventLogProcessor(otel.SpanEventLogOption{
+//		LoggerProvider: logProvider,
+//	})))
+func NewSpanEventLogProcessor(opt SpanEventLogOption) *SpanEventLogProcessor {
+	if opt.Name == "" {
+		opt.Name = spanEventLogNameDefault
+	}
+
+	return &SpanEventLogProcessor{logger: logbridge.Logger(opt.LoggerProvider, opt.Name)}
+}
+
+// OnStart do nothing
+func (p *SpanEventLogProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd emit the span event as log record
+func (p *SpanEventLogProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	events := s.Events()
+	if len(events) == 0 {
+		return
+	}
+
+	ctx := trace.ContextWithSpanContext(context.WithValue(context.Background(), spanEventLogContextKey{}, true), s.SpanContext())
+
+	for _, event := range events {
+		if event.Name == logSpanEventName {
+			continue
+		}
+
+		record := spanEventLogRecord(event)
+		if !logbridge.Enabled(ctx, p.logger, record.Severity()) {
+			continue
+		}
+
+		p.logger.Emit(ctx, record)
+	}
+}
+
+// Shutdown do nothing, the log provider is shutdown by the owner
+func (p *SpanEventLogProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush do nothing, the log provider is flushed by the owner
+func (p *SpanEventLogProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// spanEventLogRecord convert span event to info log record, exception event is error log record
+func spanEventLogRecord(event sdktrace.Event) log.Record {
+	var record log.Record
+
+	record.SetTimestamp(event.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(log.SeverityInfo)
+	record.SetSeverityText("INFO")
+	record.SetBody(log.StringValue(event.Name))
+	record.AddAttributes(log.String(string(semconv.EventNameKey), event.Name))
+
+	for _, attr := range event.Attributes {
+		if event.Name == spanEventExceptionName && attr.Key == semconv.ExceptionMessageKey {
+			record.SetBody(log.StringValue(attr.Value.AsString()))
+		}
+
+		record.AddAttributes(log.KeyValue{Key: string(attr.Key), Value: logbridge.Value(attr.Value.AsInterface())})
+	}
+
+	if event.Name == spanEventExceptionName {
+		record.SetSeverity(log.SeverityError)
+		record.SetSeverityText("ERROR")
+	}
+
+	return record
+}
+
+// logKeyValueToAttributes convert log key value to span attributes, map is flatten with dot separator
+// and slice is formatted as string
+func logKeyValueToAttributes(key string, value log.Value) []attribute.KeyValue {
+	switch value.Kind() {
+	case log.KindEmpty:
+		return nil
+	case log.KindBool:
+		return []attribute.KeyValue{attribute.Bool(key, value.AsBool())}
+	case log.KindInt64:
+		return []attribute.KeyValue{attribute.Int64(key, value.AsInt64())}
+	case log.KindFloat64:
+		return []attribute.KeyValue{attribute.Float64(key, value.AsFloat64())}
+	case log.KindString:
+		return []attribute.KeyValue{attribute.String(key, value.AsString())}
+	case log.KindMap:
+		var attrs []attribute.KeyValue
+		for _, kv := range value.AsMap() {
+			attrs = append(attrs, logKeyValueToAttributes(key+"."+kv.Key, kv.Value)...)
+		}
+
+		return attrs
+	}
+
+	return []attribute.KeyValue{attribute.String(key, value.String())}
+}
@@ -0,0 +1,167 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func emitTestSpanEventRecord(ctx context.Context, logger log.Logger, severity log.Severity, body string, attrs ...log.KeyValue) {
+	var record log.Record
+
+	record.SetSeverity(severity)
+	record.SetBody(log.StringValue(body))
+	record.AddAttributes(attrs...)
+	logger.Emit(ctx, record)
+}
+
+func testEventAttributes(event sdktrace.Event) map[string]string {
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	return attrs
+}
+
+func TestLogSpanEventProcessor(t *testing.T) {
+	processor, err := NewLogSpanEventProcessor(LogSpanEventOption{MinSeverity: WarnLogSeverity})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor)).Logger("test")
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "pay")
+	emitTestSpanEventRecord(ctx, logger, log.SeverityInfo, "below minimum severity")
+	emitTestSpanEventRecord(ctx, logger, log.SeverityWarn, "slow payment",
+		log.Int("ms", 1200), log.Map("user", log.String("id", "123")))
+	span.End()
+
+	// record without recording span is skipped
+	emitTestSpanEventRecord(context.Background(), logger, log.SeverityError, "no span")
+
+	events := recorder.Ended()[0].Events()
+	if len(events) != 1 || events[0].Name != logSpanEventName {
+		t.Fatalf("got events %v, want warn record only", events)
+	}
+
+	want := map[string]string{logSpanEventSeverityKey: "WARN", logSpanEventMessageKey: "slow payment", "ms": "1200", "user.id": "123"}
+	if got := testEventAttributes(events[0]); len(got) != len(want) {
+		t.Errorf("got event attributes %v, want %v", got, want)
+	} else {
+		for key, value := range want {
+			if got[key] != value {
+				t.Errorf("event attribute %s = %q, want %q", key, got[key], value)
+			}
+		}
+	}
+}
+
+func TestSpanEventLogProcessor(t *testing.T) {
+	exporter := &memoryLogExporter{}
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanEventLogProcessor(SpanEventLogOption{
+		LoggerProvider: newMemoryLogProvider(exporter),
+	}))).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "pay")
+	span.AddEvent("cache miss", trace.WithAttributes(attribute.String("cache.key", "user:123")))
+	span.RecordError(errors.New("card declined"))
+	span.End()
+
+	records := exporter.Records()
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	for _, record := range records {
+		if record.TraceID() != span.SpanContext().TraceID() || record.SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("record %q is not correlated to the span", record.Body().AsString())
+		}
+	}
+
+	if records[0].Body().AsString() != "cache miss" || records[0].Severity() != log.SeverityInfo {
+		t.Errorf("got record %q %v, want info cache miss", records[0].Body().AsString(), records[0].Severity())
+	}
+
+	if records[1].Body().AsString() != "card declined" || records[1].Severity() != log.SeverityError {
+		t.Errorf("got record %q %v, want error card declined", records[1].Body().AsString(), records[1].Severity())
+	}
+
+	attrs := testLogRecordAttributes(records[0])
+	if attrs[string(semconv.EventNameKey)] != "cache miss" || attrs["cache.key"] != "user:123" {
+		t.Errorf("got record attributes %v", attrs)
+	}
+}
+
+func TestSpanEventMirrorLoop(t *testing.T) {
+	processor, err := NewLogSpanEventProcessor(LogSpanEventOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := &memoryLogExporter{}
+	logProvider := newMemoryLogProvider(exporter, sdklog.WithProcessor(processor))
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanEventLogProcessor(SpanEventLogOption{LoggerProvider: logProvider})),
+		sdktrace.WithSpanProcessor(recorder),
+	).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "pay")
+	emitTestSpanEventRecord(ctx, logProvider.Logger("test"), log.SeverityWarn, "slow payment")
+	span.AddEvent("cache miss")
+	span.End()
+
+	// the log event is not emitted back as log record
+	var bodies []string
+	for _, record := range exporter.Records() {
+		bodies = append(bodies, record.Body().AsString())
+	}
+
+	if len(bodies) != 2 || bodies[0] != "slow payment" || bodies[1] != "cache miss" {
+		t.Errorf("got records %q, want original record and span event record", bodies)
+	}
+
+	// the span event record is not added back as span event
+	if events := recorder.Ended()[0].Events(); len(events) != 2 {
+		t.Errorf("got %d span events, want log event and cache miss", len(events))
+	}
+}
+
+func TestNewProvidersLogSpanEventsWithoutExporter(t *testing.T) {
+	t.Setenv(providersEnv, "log")
+	t.Setenv(exporterTypeEnv, "")
+	t.Setenv(logExporterTypeEnv, "")
+
+	providers, err := NewProviders(context.Background(), WithLogSpanEvents(LogSpanEventOption{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = providers.Shutdown(context.Background()) })
+
+	if providers.LogProvider == nil {
+		t.Fatal("log provider is not created for the span event")
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "pay")
+	emitTestSpanEventRecord(ctx, providers.LogProvider.Logger("test"), log.SeverityWarn, "slow payment")
+	span.End()
+
+	if events := recorder.Ended()[0].Events(); len(events) != 1 || events[0].Name != logSpanEventName {
+		t.Errorf("got events %v, want log event", events)
+	}
+}
@@ -0,0 +1,450 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// default statsd exporter setting
+const (
+	statsdEndpointDefault          = "udp://localhost:8125"
+	statsdUDPMaxPacketSizeDefault  = 1432
+	statsdUnixMaxPacketSizeDefault = 8192
+)
+
+// statsd metric type
+const (
+	statsdCounter = "c"
+	statsdGauge   = "g"
+)
+
+// statsdTagKeysDefault resource attribute sent as DogStatsD unified service tag
+var statsdTagKeysDefault = map[string]string{
+	"service.name":           "service",
+	"service.version":        "version",
+	"deployment.environment": "env",
+}
+
+var (
+	statsdNameReplacer        = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", "\n", "_")
+	statsdTagReplacer         = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_")
+	statsdBucketBoundReplacer = strings.NewReplacer(".", "_", "+Inf", "inf")
+)
+
+var _ sdkmetric.Exporter = (*StatsdExporter)(nil)
+
+// StatsdExporterOption option for statsd exporter
+type StatsdExporterOption struct {
+	// Endpoint statsd agent endpoint, udp://host:port or unix:///path/to/socket for datagram unix socket
+	// (default: udp://localhost:8125)
+	Endpoint string
+	// Prefix metric name prefix, joined with "." (e.g. "myapp" send "myapp.http.server.duration")
+	Prefix string
+	// TagKeys rename attribute key to tag key, resource attribute is only sent when it is in TagKeys
+	// (default: service.name to service, service.version to version and deployment.environment to env)
+	TagKeys map[string]string
+	// DisableTags don't send DogStatsD tag for plain statsd agent
+	DisableTags bool
+	// MaxPacketSize maximum datagram size, line is batched until the size (default: 1432 for udp, 8192 for unix socket)
+	MaxPacketSize int
+}
+
+// StatsdExporter metric exporter that send metric to statsd or DogStatsD agent.
+// it use delta temporality for counter and histogram so the counter is sent as statsd count,
+// up down counter and gauge is sent as statsd gauge, histogram is sent as .count and .sum count, .min and .max gauge
+// and .bucket count with le tag for each bucket, or .bucket.le_<bound> count when tag is disabled.
+// cumulative histogram, e.g. from sdkmetric.Producer that is not affected by the temporality selector,
+// is sent as the difference from the previous export
+type StatsdExporter struct {
+	opt     StatsdExporterOption
+	network string
+	address string
+
+	mu   sync.Mutex
+	conn net.Conn
+	// histograms the last cumulative histogram point of the previous export
+	histograms map[statsdHistogramKey]statsdHistogramPoint
+}
+
+// statsdHistogramKey metric name and attribute set of cumulative histogram point
+type statsdHistogramKey struct {
+	name  string
+	attrs attribute.Distinct
+}
+
+// statsdHistogramPoint count, sum and bucket counts of cumulative histogram point
+type statsdHistogramPoint struct {
+	start   time.Time
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// NewStatsdExporter create statsd metric exporter, the connection is opened on the first export
+//
+//	exporter, err := otel.NewStatsdExporter(otel.StatsdExporterOption{Endpoint: "unix:///var/run/datadog/dsd.socket"})
+//	reader := sdkmetric.NewPeriodicReader(exporter)
+func NewStatsdExporter(opt StatsdExporterOption) (*StatsdExporter, error) {
+	if opt.Endpoint == "" {
+		opt.Endpoint = statsdEndpointDefault
+	}
+
+	if opt.TagKeys == nil {
+		opt.TagKeys = statsdTagKeysDefault
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStatsdEndpoint, err)
+	}
+
+	exporter := &StatsdExporter{opt: opt}
+
+	switch endpoint.Scheme {
+	case "udp":
+		exporter.network, exporter.address = "udp", endpoint.Host
+		if opt.MaxPacketSize <= 0 {
+			exporter.opt.MaxPacketSize = statsdUDPMaxPacketSizeDefault
+		}
+	case "unix":
+		exporter.network, exporter.address = "unixgram", endpoint.Path
+		if opt.MaxPacketSize <= 0 {
+			exporter.opt.MaxPacketSize = statsdUnixMaxPacketSizeDefault
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStatsdEndpoint, opt.Endpoint)
+	}
+
+	return exporter, nil
+}
+
+// Temporality returns delta for counter and histogram, cumulative for up down counter and gauge
+func (e *StatsdExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindObservableCounter, sdkmetric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	}
+
+	return metricdata.CumulativeTemporality
+}
+
+// Aggregation returns default aggregation of the instrument kind
+func (e *StatsdExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export send the metric as statsd line, the lines are batched into datagram up to MaxPacketSize
+func (e *StatsdExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var (
+		lines        []string
+		resourceTags = e.resourceTags(rm)
+		histograms   = make(map[statsdHistogramKey]statsdHistogramPoint, len(e.histograms))
+	)
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			lines = e.appendLines(lines, m, resourceTags, histograms)
+		}
+	}
+
+	// point that is not exported anymore is dropped
+	e.histograms = histograms
+
+	var (
+		errs   []error
+		packet strings.Builder
+	)
+
+	for _, line := range lines {
+		if packet.Len() > 0 && packet.Len()+len(line)+1 > e.opt.MaxPacketSize {
+			errs = append(errs, e.write(ctx, packet.String()))
+			packet.Reset()
+		}
+
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+
+		packet.WriteString(line)
+	}
+
+	if packet.Len() > 0 {
+		errs = append(errs, e.write(ctx, packet.String()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush do nothing, metric is sent on export
+func (e *StatsdExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown close the connection
+func (e *StatsdExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+
+	return err
+}
+
+// write send the packet, the connection is reopened on the next write when it failed
+func (e *StatsdExporter) write(ctx context.Context, packet string) error {
+	if e.conn == nil {
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, e.network, e.address)
+		if err != nil {
+			return err
+		}
+
+		e.conn = conn
+	}
+
+	_, err := e.conn.Write([]byte(packet))
+	if err != nil {
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+
+	return err
+}
+
+// resourceTags returns tag from resource attribute in TagKeys
+func (e *StatsdExporter) resourceTags(rm *metricdata.ResourceMetrics) []string {
+	if e.opt.DisableTags || rm.Resource == nil {
+		return nil
+	}
+
+	var tags []string
+
+	for _, attr := range rm.Resource.Attributes() {
+		if key, ok := e.opt.TagKeys[string(attr.Key)]; ok {
+			tags = append(tags, statsdTag(key, attr.Value))
+		}
+	}
+
+	return tags
+}
+
+// appendLines append statsd line of the metric data point, cumulative histogram point is kept in histograms
+func (e *StatsdExporter) appendLines(lines []string, m metricdata.Metrics, resourceTags []string, histograms map[statsdHistogramKey]statsdHistogramPoint) []string {
+	name := statsdNameReplacer.Replace(m.Name)
+	if e.opt.Prefix != "" {
+		name = e.opt.Prefix + "." + name
+	}
+
+	switch d := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return appendStatsdSumLines(e, lines, name, d, resourceTags)
+	case metricdata.Sum[float64]:
+		return appendStatsdSumLines(e, lines, name, d, resourceTags)
+	case metricdata.Gauge[int64]:
+		for _, p := range d.DataPoints {
+			lines = append(lines, e.line(name, float64(p.Value), statsdGauge, p.Attributes, resourceTags))
+		}
+	case metricdata.Gauge[float64]:
+		for _, p := range d.DataPoints {
+			lines = append(lines, e.line(name, p.Value, statsdGauge, p.Attributes, resourceTags))
+		}
+	case metricdata.Histogram[int64]:
+		return appendStatsdHistogramLines(e, lines, name, d, resourceTags, histograms)
+	case metricdata.Histogram[float64]:
+		return appendStatsdHistogramLines(e, lines, name, d, resourceTags, histograms)
+	case metricdata.ExponentialHistogram[int64]:
+		return appendStatsdExponentialHistogramLines(e, lines, name, d, resourceTags, histograms)
+	case metricdata.ExponentialHistogram[float64]:
+		return appendStatsdExponentialHistogramLines(e, lines, name, d, resourceTags, histograms)
+	}
+
+	return lines
+}
+
+// appendStatsdSumLines send monotonic delta sum as count and the other sum as gauge
+func appendStatsdSumLines[N int64 | float64](e *StatsdExporter, lines []string, name string, d metricdata.Sum[N], resourceTags []string) []string {
+	metricType := statsdGauge
+	if d.IsMonotonic && d.Temporality == metricdata.DeltaTemporality {
+		metricType = statsdCounter
+	}
+
+	for _, p := range d.DataPoints {
+		// zero delta count is skipped so idle attribute set don't send packet
+		if metricType == statsdCounter && p.Value == 0 {
+			continue
+		}
+
+		lines = append(lines, e.line(name, float64(p.Value), metricType, p.Attributes, resourceTags))
+	}
+
+	return lines
+}
+
+func appendStatsdHistogramLines[N int64 | float64](e *StatsdExporter, lines []string, name string, d metricdata.Histogram[N], resourceTags []string, histograms map[statsdHistogramKey]statsdHistogramPoint) []string {
+	for _, p := range d.DataPoints {
+		point := statsdHistogramPoint{start: p.StartTime, count: p.Count, sum: float64(p.Sum), buckets: p.BucketCounts}
+		if d.Temporality == metricdata.CumulativeTemporality {
+			point = e.histogramDelta(name, p.Attributes, point, histograms)
+		}
+
+		if point.count == 0 {
+			continue
+		}
+
+		lines = appendStatsdSummaryLines(e, lines, name, point.count, point.sum, p.Min, p.Max, p.Attributes, resourceTags)
+
+		for i, count := range point.buckets {
+			le := "+Inf"
+			if i < len(p.Bounds) {
+				le = strconv.FormatFloat(p.Bounds[i], 'g', -1, 64)
+			}
+
+			if count == 0 {
+				continue
+			}
+
+			// plain statsd has no tag, the bound is part of the name
+			if e.opt.DisableTags {
+				lines = append(lines, e.line(name+".bucket.le_"+statsdBucketBoundReplacer.Replace(le), float64(count), statsdCounter, p.Attributes, resourceTags))
+				continue
+			}
+
+			lines = append(lines, e.line(name+".bucket", float64(count), statsdCounter, p.Attributes, resourceTags, "le:"+le))
+		}
+	}
+
+	return lines
+}
+
+func appendStatsdExponentialHistogramLines[N int64 | float64](e *StatsdExporter, lines []string, name string, d metricdata.ExponentialHistogram[N], resourceTags []string, histograms map[statsdHistogramKey]statsdHistogramPoint) []string {
+	for _, p := range d.DataPoints {
+		point := statsdHistogramPoint{start: p.StartTime, count: p.Count, sum: float64(p.Sum)}
+		if d.Temporality == metricdata.CumulativeTemporality {
+			point = e.histogramDelta(name, p.Attributes, point, histograms)
+		}
+
+		if point.count == 0 {
+			continue
+		}
+
+		lines = appendStatsdSummaryLines(e, lines, name, point.count, point.sum, p.Min, p.Max, p.Attributes, resourceTags)
+	}
+
+	return lines
+}
+
+// histogramDelta keep the cumulative point in histograms and returns the difference from the previous export,
+// the point is returned as is on the first export or when the histogram is reset
+func (e *StatsdExporter) histogramDelta(name string, attrs attribute.Set, point statsdHistogramPoint, histograms map[statsdHistogramKey]statsdHistogramPoint) statsdHistogramPoint {
+	key := statsdHistogramKey{name: name, attrs: attrs.Equivalent()}
+	// the bucket counts memory is owned by the metric data
+	histograms[key] = statsdHistogramPoint{
+		start:   point.start,
+		count:   point.count,
+		sum:     point.sum,
+		buckets: append([]uint64(nil), point.buckets...),
+	}
+
+	previous, ok := e.histograms[key]
+	if !ok || !previous.start.Equal(point.start) || previous.count > point.count || len(previous.buckets) != len(point.buckets) {
+		return point
+	}
+
+	delta := statsdHistogramPoint{
+		start:   point.start,
+		count:   point.count - previous.count,
+		sum:     point.sum - previous.sum,
+		buckets: make([]uint64, len(point.buckets)),
+	}
+
+	for i, count := range point.buckets {
+		if count < previous.buckets[i] {
+			return point
+		}
+
+		delta.buckets[i] = count - previous.buckets[i]
+	}
+
+	return delta
+}
+
+// appendStatsdSummaryLines send histogram count, sum, min and max
+func appendStatsdSummaryLines[N int64 | float64](e *StatsdExporter, lines []string, name string, count uint64, sum float64, minExtrema, maxExtrema metricdata.Extrema[N], attrs attribute.Set, resourceTags []string) []string {
+	lines = append(lines,
+		e.line(name+".count", float64(count), statsdCounter, attrs, resourceTags),
+		e.line(name+".sum", sum, statsdCounter, attrs, resourceTags),
+	)
+
+	if value, ok := minExtrema.Value(); ok {
+		lines = append(lines, e.line(name+".min", float64(value), statsdGauge, attrs, resourceTags))
+	}
+
+	if value, ok := maxExtrema.Value(); ok {
+		lines = append(lines, e.line(name+".max", float64(value), statsdGauge, attrs, resourceTags))
+	}
+
+	return lines
+}
+
+// line format "name:value|type|#tag:value,..." statsd line,
+// negative gauge is sent as "name:0|g" line followed by the value line because statsd treat signed gauge as delta
+func (e *StatsdExporter) line(name string, value float64, metricType string, attrs attribute.Set, resourceTags []string, extraTags ...string) string {
+	line := name + ":" + strconv.FormatFloat(value, 'f', -1, 64) + "|" + metricType + e.tags(attrs, resourceTags, extraTags)
+
+	if metricType == statsdGauge && value < 0 {
+		return name + ":0|" + statsdGauge + e.tags(attrs, resourceTags, extraTags) + "\n" + line
+	}
+
+	return line
+}
+
+// tags returns "|#tag:value,..." of the resource tags, attributes and extra tags, empty when tag is disabled
+func (e *StatsdExporter) tags(attrs attribute.Set, resourceTags, extraTags []string) string {
+	if e.opt.DisableTags {
+		return ""
+	}
+
+	tags := make([]string, 0, attrs.Len()+len(resourceTags)+len(extraTags))
+	tags = append(tags, resourceTags...)
+
+	for _, attr := range attrs.ToSlice() {
+		key := string(attr.Key)
+		if tagKey, ok := e.opt.TagKeys[key]; ok {
+			key = tagKey
+		}
+
+		tags = append(tags, statsdTag(key, attr.Value))
+	}
+
+	tags = append(tags, extraTags...)
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	return "|#" + strings.Join(tags, ",")
+}
+
+func statsdTag(key string, value attribute.Value) string {
+	return statsdTagReplacer.Replace(key) + ":" + statsdTagReplacer.Replace(value.Emit())
+}
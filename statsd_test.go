@@ -0,0 +1,300 @@
+package otel
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func testStatsdMetrics() *metricdata.ResourceMetrics {
+	attrs := attribute.NewSet(attribute.String("http.method", "GET"))
+
+	return &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(semconv.ServiceName("payments"), semconv.HostName("node-1")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "test"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "requests",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.DeltaTemporality,
+						IsMonotonic: true,
+						DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}},
+					},
+				},
+				{
+					Name: "balance",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{{Attributes: attrs, Value: -2.5}},
+					},
+				},
+				{
+					Name: "latency",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attrs,
+							Bounds:       []float64{0.5, 1},
+							BucketCounts: []uint64{2, 0, 1},
+							Count:        3,
+							Sum:          2.5,
+							Min:          metricdata.NewExtrema(0.1),
+							Max:          metricdata.NewExtrema(2.0),
+						}},
+					},
+				},
+			},
+		}},
+	}
+}
+
+// listenTestStatsd returns udp listener and the statsd endpoint of the listener
+func listenTestStatsd(t *testing.T) (net.PacketConn, string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, "udp://" + conn.LocalAddr().String()
+}
+
+// readTestStatsd returns the received packets until no packet is received for 200ms
+func readTestStatsd(conn net.PacketConn) []string {
+	var (
+		packets []string
+		buf     = make([]byte, 65536)
+	)
+
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return packets
+		}
+
+		packets = append(packets, string(buf[:n]))
+	}
+}
+
+// exportTestStatsd export the metrics to udp listener and returns the received packets
+func exportTestStatsd(t *testing.T, opt StatsdExporterOption) []string {
+	t.Helper()
+
+	conn, endpoint := listenTestStatsd(t)
+	opt.Endpoint = endpoint
+
+	exporter, err := NewStatsdExporter(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.Background()) })
+
+	err = exporter.Export(context.Background(), testStatsdMetrics())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return readTestStatsd(conn)
+}
+
+// cumulativeTestProducer producer that returns cumulative histogram with the bucket counts
+type cumulativeTestProducer struct {
+	start   time.Time
+	buckets []uint64
+	sum     float64
+}
+
+func (p *cumulativeTestProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	var count uint64
+	for _, bucket := range p.buckets {
+		count += bucket
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope: instrumentation.Scope{Name: "producer"},
+		Metrics: []metricdata.Metrics{{
+			Name: "go.schedule.duration",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{{
+					StartTime:    p.start,
+					Time:         time.Now(),
+					Bounds:       []float64{1},
+					BucketCounts: append([]uint64(nil), p.buckets...),
+					Count:        count,
+					Sum:          p.sum,
+				}},
+			},
+		}},
+	}}, nil
+}
+
+func TestStatsdExporterDogStatsD(t *testing.T) {
+	packets := exportTestStatsd(t, StatsdExporterOption{Prefix: "shop"})
+	if len(packets) != 1 {
+		t.Fatalf("got %d packets, want 1", len(packets))
+	}
+
+	tags := "|#service:payments,http.method:GET"
+	want := []string{
+		"shop.requests:3|c" + tags,
+		"shop.balance:0|g" + tags,
+		"shop.balance:-2.5|g" + tags,
+		"shop.latency.count:3|c" + tags,
+		"shop.latency.sum:2.5|c" + tags,
+		"shop.latency.min:0.1|g" + tags,
+		"shop.latency.max:2|g" + tags,
+		"shop.latency.bucket:2|c" + tags + ",le:0.5",
+		"shop.latency.bucket:1|c" + tags + ",le:+Inf",
+	}
+
+	if got := strings.Split(packets[0], "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got lines\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestStatsdExporterPlainStatsd(t *testing.T) {
+	packets := exportTestStatsd(t, StatsdExporterOption{DisableTags: true})
+	if len(packets) != 1 {
+		t.Fatalf("got %d packets, want 1", len(packets))
+	}
+
+	want := []string{
+		"requests:3|c",
+		"balance:0|g",
+		"balance:-2.5|g",
+		"latency.count:3|c",
+		"latency.sum:2.5|c",
+		"latency.min:0.1|g",
+		"latency.max:2|g",
+		"latency.bucket.le_0_5:2|c",
+		"latency.bucket.le_inf:1|c",
+	}
+
+	if got := packets[0]; got != strings.Join(want, "\n") {
+		t.Errorf("got lines\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestStatsdExporterMaxPacketSize(t *testing.T) {
+	packets := exportTestStatsd(t, StatsdExporterOption{DisableTags: true, MaxPacketSize: 40})
+
+	var lines []string
+
+	for _, packet := range packets {
+		if len(packet) > 40 {
+			t.Errorf("packet %q is over max packet size", packet)
+		}
+
+		lines = append(lines, strings.Split(packet, "\n")...)
+	}
+
+	if len(lines) != 9 {
+		t.Errorf("got %d lines, want 9", len(lines))
+	}
+
+	for i, line := range lines {
+		if line == "balance:-2.5|g" && (i == 0 || lines[i-1] != "balance:0|g") {
+			t.Error("negative gauge is not sent after zero gauge in the same packet")
+		}
+	}
+}
+
+func TestStatsdExporterCumulativeProducer(t *testing.T) {
+	conn, endpoint := listenTestStatsd(t)
+
+	exporter, err := NewStatsdExporter(StatsdExporterOption{Endpoint: endpoint, DisableTags: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	producer := &cumulativeTestProducer{start: time.Now(), buckets: []uint64{2, 1}, sum: 3}
+	reader := sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithProducer(producer), sdkmetric.WithInterval(time.Hour))
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	collect := func() string {
+		t.Helper()
+
+		err := provider.ForceFlush(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return strings.Join(readTestStatsd(conn), "\n")
+	}
+
+	tests := []struct {
+		name    string
+		buckets []uint64
+		sum     float64
+		start   time.Time
+		want    []string
+	}{
+		{
+			name:    "first export send the cumulative value",
+			buckets: []uint64{2, 1},
+			sum:     3,
+			want: []string{
+				"go.schedule.duration.count:3|c",
+				"go.schedule.duration.sum:3|c",
+				"go.schedule.duration.bucket.le_1:2|c",
+				"go.schedule.duration.bucket.le_inf:1|c",
+			},
+		},
+		{
+			name:    "next export send the difference",
+			buckets: []uint64{3, 2},
+			sum:     5.5,
+			want: []string{
+				"go.schedule.duration.count:2|c",
+				"go.schedule.duration.sum:2.5|c",
+				"go.schedule.duration.bucket.le_1:1|c",
+				"go.schedule.duration.bucket.le_inf:1|c",
+			},
+		},
+		{
+			name:    "unchanged histogram is not sent",
+			buckets: []uint64{3, 2},
+			sum:     5.5,
+		},
+		{
+			name:    "reset histogram send the cumulative value",
+			buckets: []uint64{1, 0},
+			sum:     0.5,
+			start:   producer.start.Add(time.Minute),
+			want: []string{
+				"go.schedule.duration.count:1|c",
+				"go.schedule.duration.sum:0.5|c",
+				"go.schedule.duration.bucket.le_1:1|c",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		producer.buckets, producer.sum = tt.buckets, tt.sum
+		if !tt.start.IsZero() {
+			producer.start = tt.start
+		}
+
+		if got, want := collect(), strings.Join(tt.want, "\n"); got != want {
+			t.Errorf("%s: got lines\n%s\nwant\n%s", tt.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erry-az/otel-go/logbridge"
+	"go.opentelemetry.io/otel"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// default standard log redirect setting
+const (
+	stdLogNameDefault = instrumentationName + "/stdlog"
+	stdLogPrefixKey   = "log.prefix"
+	stdLogDateLayout  = "2006/01/02"
+	stdLogTimeLayout  = "15:04:05"
+	stdLogMicroLayout = ".000000"
+)
+
+var (
+	// otelDefaultErrorHandler otel error handler before any handler is set, it write the error to log.Default()
+	otelDefaultErrorHandler = otel.GetErrorHandler()
+
+	stdLogErrors           = &stdLogErrorHandler{}
+	stdLogErrorHandlerOnce sync.Once
+)
+
+// StdLogOption option for redirecting standard library log
+type StdLogOption struct {
+	// Logger standard logger to redirect (default: log.Default())
+	Logger *log.Logger
+	// Name instrumentation scope name of the logger (default: github.com/erry-az/otel-go/stdlog)
+	Name string
+	// Tee keep writing the log line to the previous writer of the logger
+	Tee bool
+}
+
+// StdLogWriter writer for standard logger that parse the log line with the logger prefix and flags
+// and emit it as info log record, date and time is used as timestamp and file as code attribute
+type StdLogWriter struct {
+	logger    otellog.Logger
+	stdLogger *log.Logger
+	tee       io.Writer
+}
+
+// NewStdLogWriter create writer for the standard logger of the option, tee to the current writer when option Tee is set
+//
+//	logger := log.New(io.Discard, "payments: ", log.LstdFlags|log.Lshortfile)
+//	logger.SetOutput(otel.NewStdLogWriter(providers.LogProvider, otel.StdLogOption{Logger: logger}))
+func NewStdLogWriter(provider otellog.LoggerProvider, opt StdLogOption) *StdLogWriter {
+	if opt.Logger == nil {
+		opt.Logger = log.Default()
+	}
+
+	if opt.Name == "" {
+		opt.Name = stdLogNameDefault
+	}
+
+	writer := &StdLogWriter{
+		logger:    logbridge.Logger(provider, opt.Name),
+		stdLogger: opt.Logger,
+	}
+
+	if opt.Tee {
+		writer.tee = opt.Logger.Writer()
+	}
+
+	return writer
+}
+
+// RedirectStdLog set the standard logger output to StdLogWriter, call restore to set back the previous writer.
+// when log.Default() is redirected and the otel error handler is not set, otel error is written to the previous writer
+// instead of log.Default(), so failed export is not emitted back to the log provider
+// and doesn't deadlock the standard logger. error handler that write to log.Default() must not be set while redirected
+//
+//	restore := otel.RedirectStdLog(providers.LogProvider, otel.StdLogOption{Tee: true})
+//	defer restore()
+func RedirectStdLog(provider otellog.LoggerProvider, opt StdLogOption) (restore func()) {
+	if opt.Logger == nil {
+		opt.Logger = log.Default()
+	}
+
+	previous := opt.Logger.Writer()
+
+	if opt.Logger == log.Default() {
+		stdLogErrorHandlerOnce.Do(func() {
+			if otel.GetErrorHandler() == otelDefaultErrorHandler {
+				otel.SetErrorHandler(stdLogErrors)
+			}
+		})
+
+		stdLogErrors.setWriter(previous)
+	}
+
+	opt.Logger.SetOutput(NewStdLogWriter(provider, opt))
+
+	return func() {
+		opt.Logger.SetOutput(previous)
+
+		if opt.Logger == log.Default() {
+			stdLogErrors.setWriter(nil)
+		}
+	}
+}
+
+// Write emit the log line as info record and write it to the previous writer when tee is enabled
+func (w *StdLogWriter) Write(p []byte) (int, error) {
+	if logbridge.Enabled(context.Background(), w.logger, otellog.SeverityInfo) {
+		w.logger.Emit(context.Background(), w.record(string(p)))
+	}
+
+	if w.tee != nil {
+		return w.tee.Write(p)
+	}
+
+	return len(p), nil
+}
+
+// record parse the log line written by the standard logger,
+// the line is "prefix date time.micro file:line: msgprefix message\n" based on the logger flags
+func (w *StdLogWriter) record(line string) otellog.Record {
+	var (
+		record otellog.Record
+		flags  = w.stdLogger.Flags()
+		prefix = w.stdLogger.Prefix()
+	)
+
+	record.SetSeverity(otellog.SeverityInfo)
+	record.SetSeverityText("INFO")
+
+	line = strings.TrimSuffix(line, "\n")
+
+	if flags&log.Lmsgprefix == 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+
+	if layout := stdLogTimeLayoutFromFlags(flags); layout != "" && len(line) > len(layout) {
+		if timestamp, ok := parseStdLogTime(layout, line[:len(layout)], flags); ok {
+			record.SetTimestamp(timestamp)
+			line = line[len(layout)+1:]
+		}
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if i := strings.Index(line, ": "); i > 0 {
+			if j := strings.LastIndex(line[:i], ":"); j > 0 {
+				lineNo, err := strconv.Atoi(line[j+1 : i])
+				if err == nil {
+					record.AddAttributes(logbridge.CodeAttributes(line[:j], lineNo, "")...)
+					line = line[i+2:]
+				}
+			}
+		}
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		record.AddAttributes(otellog.String(stdLogPrefixKey, strings.TrimSuffix(prefix, ":")))
+	}
+
+	record.SetBody(otellog.StringValue(line))
+
+	return record
+}
+
+// stdLogTimeLayoutFromFlags returns time layout written by the standard logger, empty when date and time is not written
+func stdLogTimeLayoutFromFlags(flags int) string {
+	var layouts []string
+
+	if flags&log.Ldate != 0 {
+		layouts = append(layouts, stdLogDateLayout)
+	}
+
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout := stdLogTimeLayout
+		if flags&log.Lmicroseconds != 0 {
+			layout += stdLogMicroLayout
+		}
+
+		layouts = append(layouts, layout)
+	}
+
+	return strings.Join(layouts, " ")
+}
+
+// parseStdLogTime parse date and time of the log line in utc or local time, today is used when the date is not written
+func parseStdLogTime(layout, value string, flags int) (time.Time, bool) {
+	location := time.Local
+	if flags&log.LUTC != 0 {
+		location = time.UTC
+	}
+
+	timestamp, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if flags&log.Ldate == 0 {
+		now := time.Now().In(location)
+		timestamp = time.Date(now.Year(), now.Month(), now.Day(),
+			timestamp.Hour(), timestamp.Minute(), timestamp.Second(), timestamp.Nanosecond(), location)
+	}
+
+	return timestamp, true
+}
+
+// stdLogErrorHandler otel error handler that write the error to the previous writer of the redirected log.Default()
+type stdLogErrorHandler struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// Handle write the error with log.Default() prefix and flags to the previous writer,
+// it is written to log.Default() when it is not redirected
+func (h *stdLogErrorHandler) Handle(err error) {
+	h.mu.Lock()
+	writer := h.writer
+	h.mu.Unlock()
+
+	if writer == nil {
+		log.Print(err)
+		return
+	}
+
+	// log.Default() output lock is held when the error come from StdLogWriter, use new logger for the previous writer
+	_ = log.New(writer, log.Prefix(), log.Flags()).Output(2, err.Error())
+}
+
+func (h *stdLogErrorHandler) setWriter(writer io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writer = writer
+}
@@ -0,0 +1,94 @@
+package otel
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestRedirectStdLogExportError(t *testing.T) {
+	var (
+		output   bytes.Buffer
+		previous = log.Writer()
+		flags    = log.Flags()
+	)
+
+	log.SetOutput(&output)
+	log.SetFlags(0)
+
+	t.Cleanup(func() {
+		log.SetOutput(previous)
+		log.SetFlags(flags)
+	})
+
+	provider := newMemoryLogProvider(&memoryLogExporter{err: errTestExport})
+	restore := RedirectStdLog(provider, StdLogOption{})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		log.Print("payment failed")
+		restore()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("standard logger is deadlocked by export error")
+	}
+
+	if got := output.String(); got != errTestExport.Error()+"\n" {
+		t.Errorf("previous writer got %q, want export error only", got)
+	}
+
+	if log.Writer() != &output {
+		t.Error("previous writer is not restored")
+	}
+}
+
+func TestStdLogWriterRecord(t *testing.T) {
+	exporter := &memoryLogExporter{}
+	logger := log.New(nil, "payments: ", log.Ldate|log.Ltime|log.Lmicroseconds|log.LUTC|log.Lshortfile)
+	logger.SetOutput(NewStdLogWriter(newMemoryLogProvider(exporter), StdLogOption{Logger: logger}))
+
+	logger.Print("charge accepted")
+
+	records := exporter.Records()
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+
+	record := records[0]
+
+	if got := record.Body().AsString(); got != "charge accepted" {
+		t.Errorf("body %q, want %q", got, "charge accepted")
+	}
+
+	if record.Severity() != otellog.SeverityInfo {
+		t.Errorf("severity %s, want INFO", record.Severity())
+	}
+
+	if since := time.Since(record.Timestamp()); since < 0 || since > time.Minute {
+		t.Errorf("timestamp %s is not parsed from the log line", record.Timestamp())
+	}
+
+	attrs := map[string]string{}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+
+	if attrs[stdLogPrefixKey] != "payments" {
+		t.Errorf("prefix attribute %q, want payments", attrs[stdLogPrefixKey])
+	}
+
+	if !strings.HasSuffix(attrs["code.filepath"], "stdlog_test.go") {
+		t.Errorf("code.filepath attribute %q, want stdlog_test.go", attrs["code.filepath"])
+	}
+}
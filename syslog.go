@@ -0,0 +1,314 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// default syslog exporter setting
+const (
+	syslogEndpointDefault         = "unix:///dev/log"
+	syslogFacilityDefault         = UserSyslogFacility
+	syslogStructuredDataIDDefault = "otel@32473"
+	syslogTimestampLayout         = "2006-01-02T15:04:05.000000Z07:00"
+	syslogNilValue                = "-"
+	syslogMaxAppNameLength        = 48
+	syslogMaxHostnameLength       = 255
+	syslogMaxParamNameLength      = 32
+)
+
+// syslog severity, RFC 5424 section 6.2.1
+const (
+	syslogSeverityCritical = 2
+	syslogSeverityError    = 3
+	syslogSeverityWarning  = 4
+	syslogSeverityInfo     = 6
+	syslogSeverityDebug    = 7
+)
+
+// log record field sent as syslog structured data param and journald field
+const (
+	logScopeNameKey = "otel.scope.name"
+	logTraceIDKey   = "trace_id"
+	logSpanIDKey    = "span_id"
+)
+
+var syslogParamValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+var _ sdklog.Exporter = (*SyslogExporter)(nil)
+
+// SyslogExporterOption option for syslog exporter
+type SyslogExporterOption struct {
+	// Endpoint syslog daemon endpoint, unix:///path/to/socket for datagram unix socket, udp://host:port
+	// or tcp://host:port with octet counting framing (default: unix:///dev/log)
+	Endpoint string
+	// Facility syslog facility of every message (default: user)
+	Facility SyslogFacility
+	// AppName APP-NAME header (default: service.name resource attribute)
+	AppName string
+	// Hostname HOSTNAME header (default: os.Hostname)
+	Hostname string
+	// StructuredDataID SD-ID of the structured data that hold the attributes (default: otel@32473)
+	StructuredDataID string
+}
+
+// SyslogExporter log exporter that send record as RFC 5424 syslog message,
+// severity is mapped to syslog severity, body to MSG and scope name, trace id, span id and attributes
+// to structured data param
+type SyslogExporter struct {
+	opt      SyslogExporterOption
+	network  string
+	address  string
+	priority int
+	procID   string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogExporter create syslog log exporter, the connection is opened on the first export
+//
+//	exporter, err := otel.NewSyslogExporter(otel.SyslogExporterOption{Endpoint: "udp://localhost:514", Facility: otel.Local0SyslogFacility})
+//	provider, err := otel.NewLogProvider(res, exporter)
+func NewSyslogExporter(opt SyslogExporterOption) (*SyslogExporter, error) {
+	if opt.Endpoint == "" {
+		opt.Endpoint = syslogEndpointDefault
+	}
+
+	if opt.Facility == "" {
+		opt.Facility = syslogFacilityDefault
+	}
+
+	if opt.StructuredDataID == "" {
+		opt.StructuredDataID = syslogStructuredDataIDDefault
+	}
+
+	if opt.Hostname == "" {
+		opt.Hostname, _ = os.Hostname()
+	}
+
+	facility, ok := syslogFacilities[opt.Facility]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSyslogFacility, opt.Facility)
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSyslogEndpoint, err)
+	}
+
+	exporter := &SyslogExporter{
+		opt:      opt,
+		priority: facility * 8,
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+
+	switch endpoint.Scheme {
+	case "udp", "tcp":
+		exporter.network, exporter.address = endpoint.Scheme, endpoint.Host
+	case "unix":
+		exporter.network, exporter.address = "unixgram", endpoint.Path
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSyslogEndpoint, opt.Endpoint)
+	}
+
+	return exporter, nil
+}
+
+// Export send every record as syslog message, one datagram per message for udp and unix socket
+func (e *SyslogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var errs []error
+
+	for i := range records {
+		message := e.message(&records[i])
+		if e.network == "tcp" {
+			message = strconv.Itoa(len(message)) + " " + message
+		}
+
+		errs = append(errs, e.write(ctx, message))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush do nothing, record is sent on export
+func (e *SyslogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown close the connection
+func (e *SyslogExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+
+	return err
+}
+
+// write send the message, the connection is reopened on the next write when it failed
+func (e *SyslogExporter) write(ctx context.Context, message string) error {
+	if e.conn == nil {
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, e.network, e.address)
+		if err != nil {
+			return err
+		}
+
+		e.conn = conn
+	}
+
+	_, err := e.conn.Write([]byte(message))
+	if err != nil {
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+
+	return err
+}
+
+// message format the record as "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID param="value"] MSG"
+func (e *SyslogExporter) message(record *sdklog.Record) string {
+	var b strings.Builder
+
+	timestamp := record.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = record.ObservedTimestamp()
+	}
+
+	appName := e.opt.AppName
+	if appName == "" {
+		appName = logRecordServiceName(record)
+	}
+
+	b.WriteString("<" + strconv.Itoa(e.priority+syslogSeverity(record.Severity())) + ">1 ")
+	b.WriteString(timestamp.Format(syslogTimestampLayout) + " ")
+	b.WriteString(syslogHeader(e.opt.Hostname, syslogMaxHostnameLength) + " ")
+	b.WriteString(syslogHeader(appName, syslogMaxAppNameLength) + " ")
+	b.WriteString(e.procID + " " + syslogNilValue + " ")
+
+	b.WriteString("[" + e.opt.StructuredDataID)
+
+	for _, attr := range logRecordFields(record) {
+		b.WriteString(" " + syslogParamName(string(attr.Key)) + `="` + syslogParamValueReplacer.Replace(attr.Value.Emit()) + `"`)
+	}
+
+	b.WriteString("]")
+
+	if body := record.Body(); body.Kind() != log.KindEmpty {
+		b.WriteString(" " + body.String())
+	}
+
+	return b.String()
+}
+
+// syslogSeverity map log severity to syslog severity, undefined severity is info
+func syslogSeverity(severity log.Severity) int {
+	switch {
+	case severity >= log.SeverityFatal1:
+		return syslogSeverityCritical
+	case severity >= log.SeverityError1:
+		return syslogSeverityError
+	case severity >= log.SeverityWarn1:
+		return syslogSeverityWarning
+	case severity >= log.SeverityInfo1, severity == log.SeverityUndefined:
+		return syslogSeverityInfo
+	}
+
+	return syslogSeverityDebug
+}
+
+// syslogHeader returns printable header value up to the max length, empty is nil value "-"
+func syslogHeader(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < '!' || r > '~' {
+			return '_'
+		}
+
+		return r
+	}, value)
+
+	if value == "" {
+		return syslogNilValue
+	}
+
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	return value
+}
+
+// syslogParamName returns PARAM-NAME of printable character except '=', ']' and '"' up to 32 character
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	if len(name) > syslogMaxParamNameLength {
+		name = name[:syslogMaxParamNameLength]
+	}
+
+	return name
+}
+
+// logRecordServiceName returns service.name resource attribute of the record
+func logRecordServiceName(record *sdklog.Record) string {
+	resource := record.Resource()
+
+	value, ok := resource.Set().Value(semconv.ServiceNameKey)
+	if !ok {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+// logRecordFields returns scope name, trace id, span id and flatten attributes of the record
+func logRecordFields(record *sdklog.Record) []attribute.KeyValue {
+	fields := make([]attribute.KeyValue, 0, record.AttributesLen()+3)
+
+	if name := record.InstrumentationScope().Name; name != "" {
+		fields = append(fields, attribute.String(logScopeNameKey, name))
+	}
+
+	if traceID := record.TraceID(); traceID.IsValid() {
+		fields = append(fields, attribute.String(logTraceIDKey, traceID.String()))
+	}
+
+	if spanID := record.SpanID(); spanID.IsValid() {
+		fields = append(fields, attribute.String(logSpanIDKey, spanID.String()))
+	}
+
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		fields = append(fields, logKeyValueToAttributes(kv.Key, kv.Value)...)
+		return true
+	})
+
+	return fields
+}
@@ -0,0 +1,169 @@
+package otel
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var testLogTimestamp = time.Date(2024, 5, 1, 10, 20, 30, 123456000, time.UTC)
+
+// emitTestLogRecord emit the record to the exporter with simple processor
+func emitTestLogRecord(t *testing.T, exporter sdklog.Exporter, severity log.Severity, body string, attrs ...log.KeyValue) {
+	t.Helper()
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource.NewSchemaless(semconv.ServiceName("payments"))),
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+	)
+
+	var record log.Record
+	record.SetTimestamp(testLogTimestamp)
+	record.SetSeverity(severity)
+	record.SetBody(log.StringValue(body))
+	record.AddAttributes(attrs...)
+
+	provider.Logger("test").Emit(context.Background(), record)
+
+	err := provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listenTestUnixgram returns datagram unix socket in temp dir
+func listenTestUnixgram(t *testing.T) *net.UnixConn {
+	t.Helper()
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: filepath.Join(t.TempDir(), "log.sock"), Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func readTestPacket(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	buf := make([]byte, 65536)
+
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(buf[:n])
+}
+
+func TestSyslogExporterUnixgram(t *testing.T) {
+	conn := listenTestUnixgram(t)
+
+	exporter, err := NewSyslogExporter(SyslogExporterOption{
+		Endpoint: "unix://" + conn.LocalAddr().String(),
+		Facility: Local0SyslogFacility,
+		Hostname: "node 1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emitTestLogRecord(t, exporter, log.SeverityError, "charge failed", log.String("reason", `card "x" [bank] \ declined`))
+
+	want := `<131>1 2024-05-01T10:20:30.123456Z node_1 payments ` + strconv.Itoa(os.Getpid()) +
+		` - [otel@32473 otel.scope.name="test" reason="card \"x\" [bank\] \\ declined"] charge failed`
+
+	if got := readTestPacket(t, conn); got != want {
+		t.Errorf("got message\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSyslogExporterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	exporter, err := NewSyslogExporter(SyslogExporterOption{Endpoint: "udp://" + conn.LocalAddr().String(), AppName: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emitTestLogRecord(t, exporter, log.SeverityWarn, "slow")
+
+	got := readTestPacket(t, conn)
+
+	// user facility 1 * 8 + warning 4
+	if !strings.HasPrefix(got, "<12>1 2024-05-01T10:20:30.123456Z ") || !strings.Contains(got, " shop ") || !strings.HasSuffix(got, "] slow") {
+		t.Errorf("unexpected message %s", got)
+	}
+}
+
+func TestSyslogExporterTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	exporter, err := NewSyslogExporter(SyslogExporterOption{Endpoint: "tcp://" + listener.Addr().String(), Hostname: "node-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emitTestLogRecord(t, exporter, log.SeverityInfo, "first")
+	emitTestLogRecord(t, exporter, log.SeverityDebug, "second\nline")
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+
+	for _, want := range []string{"<14>1 ", "<15>1 "} {
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+		if err != nil {
+			t.Fatalf("invalid octet count %q", length)
+		}
+
+		message := make([]byte, n)
+		if _, err := io.ReadFull(reader, message); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(string(message), want) {
+			t.Errorf("message %q, want prefix %q", message, want)
+		}
+	}
+
+	_ = exporter.Shutdown(context.Background())
+}
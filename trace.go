@@ -66,6 +66,30 @@ func NewTraceProvider(res *resource.Resource, exporter sdktrace.SpanExporter, op
 	), nil
 }
 
+// NewIDGenerator new trace and span id generator with defined type
+// random returns nil so the sdk default random id generator is used
+// xray returns AWS X-Ray compatible id generator, should be used with XRayPropagator
+func NewIDGenerator(generatorType IDGeneratorType) (sdktrace.IDGenerator, error) {
+	switch generatorType {
+	case RandomIDGeneratorType:
+		return nil, nil
+	case XRayIDGeneratorType:
+		return NewXRayIDGenerator(), nil
+	}
+
+	return nil, ErrInvalidIDGeneratorType
+}
+
+// NewIDGeneratorPropagators returns additional propagators required by the id generator type
+// xray returns XRayPropagator
+func NewIDGeneratorPropagators(generatorType IDGeneratorType) []propagation.TextMapPropagator {
+	if generatorType == XRayIDGeneratorType {
+		return []propagation.TextMapPropagator{XRayPropagator{}}
+	}
+
+	return nil
+}
+
 // SetGlobalTraceProvider set trace provider as global trace provider
 func SetGlobalTraceProvider(traceProvider *sdktrace.TracerProvider) {
 	otel.SetTracerProvider(traceProvider)
@@ -82,12 +106,24 @@ func SetGlobalContextPropagation(propagators ...propagation.TextMapPropagator) {
 	)
 }
 
-// InitTraceProvider using basic init trace without option
+// InitTraceProvider using basic init trace with optional trace provider option
 // this will do init trace exporter by exporterType argument
-// pass the exporter to trace provider
+// pass the exporter and opts to trace provider
+// use X-Ray id generator when OTEL_TRACES_ID_GENERATOR=xray
 // set new trace provider to global
 // and set global context propagation using trace context and baggage as propagator
-func InitTraceProvider(ctx context.Context, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+func InitTraceProvider(ctx context.Context, res *resource.Resource, opts ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	idGeneratorType, err := getTraceIDGeneratorTypeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return initTraceProvider(ctx, res, idGeneratorType, opts...)
+}
+
+// initTraceProvider init trace provider with the id generator type read once by the caller,
+// so NewProviders use the same type for the trace provider and the propagator
+func initTraceProvider(ctx context.Context, res *resource.Resource, idGeneratorType IDGeneratorType, opts ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
 	exporterType := getTraceExporterTypeFromEnv()
 
 	if exporterType == "" {
@@ -99,7 +135,17 @@ func InitTraceProvider(ctx context.Context, res *resource.Resource) (*sdktrace.T
 		return nil, err
 	}
 
-	traceProvider, err := NewTraceProvider(res, exporter)
+	idGenerator, err := NewIDGenerator(idGeneratorType)
+	if err != nil {
+		return nil, err
+	}
+
+	// option from argument is applied last so it can override the env
+	if idGenerator != nil {
+		opts = append([]sdktrace.TracerProviderOption{sdktrace.WithIDGenerator(idGenerator)}, opts...)
+	}
+
+	traceProvider, err := NewTraceProvider(res, exporter, opts...)
 	if err != nil {
 		return nil, err
 	}
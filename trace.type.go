@@ -16,3 +16,19 @@ const (
 
 // ErrInvalidTraceExporterType invalid trace exporter type error
 var ErrInvalidTraceExporterType = errors.New("invalid trace exporter type")
+
+// IDGeneratorType trace and span id generator type
+type IDGeneratorType string
+
+const (
+	// RandomIDGeneratorType random id generator, the sdk default
+	RandomIDGeneratorType IDGeneratorType = "random"
+	// XRayIDGeneratorType AWS X-Ray compatible time prefixed id generator
+	XRayIDGeneratorType IDGeneratorType = "xray"
+)
+
+// ErrInvalidIDGeneratorType invalid id generator type error
+var ErrInvalidIDGeneratorType = errors.New("invalid id generator type")
+
+// ErrSpanCardinality span name or guarded attribute value over the cardinality guard limit error
+var ErrSpanCardinality = errors.New("span cardinality over the limit")
@@ -0,0 +1,252 @@
+package otel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"gopkg.in/yaml.v3"
+)
+
+// default exponential histogram setting, same as sdk default
+const (
+	exponentialHistogramMaxSizeDefault  = 160
+	exponentialHistogramMaxScaleDefault = 20
+)
+
+// exponential histogram max scale range from otel metric sdk spec
+const (
+	exponentialHistogramMinScale = -10
+	exponentialHistogramMaxScale = 20
+)
+
+// histogramBucketPresets boundaries of the histogram bucket preset
+var histogramBucketPresets = map[HistogramBucketPreset][]float64{
+	RPCFastBucketPreset:      {0.0005, 0.001, 0.0025, 0.005, 0.0075, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 1},
+	HTTPDefaultBucketPreset:  {0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10},
+	BatchSecondsBucketPreset: {1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+	BytesSizeBucketPreset:    {64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864},
+}
+
+// Buckets returns copy of the preset boundaries, e.g. for metric.WithExplicitBucketBoundaries
+func (p HistogramBucketPreset) Buckets() ([]float64, error) {
+	buckets, ok := histogramBucketPresets[p]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHistogramBucketPreset, p)
+	}
+
+	return append([]float64(nil), buckets...), nil
+}
+
+// NewHistogramBucketPresetView create view that apply the bucket preset to histogram instrument matching the name glob
+//
+//	view, err := otel.NewHistogramBucketPresetView("rpc.client.*", otel.RPCFastBucketPreset)
+//	otel.NewMetricProvider(res, reader, sdkmetric.WithView(view))
+func NewHistogramBucketPresetView(instrument string, preset HistogramBucketPreset) (sdkmetric.View, error) {
+	return NewMetricView(MetricViewConfig{
+		Instrument:   instrument,
+		Kind:         HistogramInstrumentKind,
+		BucketPreset: preset,
+	})
+}
+
+// NewExponentialHistogramAggregation create base2 exponential histogram aggregation,
+// zero max size use the sdk default 160 and max scale is used as is, 0 is valid scale and the sdk default is 20
+func NewExponentialHistogramAggregation(maxSize, maxScale int32) (sdkmetric.Aggregation, error) {
+	if maxSize == 0 {
+		maxSize = exponentialHistogramMaxSizeDefault
+	}
+
+	if maxSize < 0 || maxScale < exponentialHistogramMinScale || maxScale > exponentialHistogramMaxScale {
+		return nil, fmt.Errorf("%w: max size %d, max scale %d", ErrInvalidExponentialHistogram, maxSize, maxScale)
+	}
+
+	return sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: maxSize, MaxScale: maxScale}, nil
+}
+
+// NewMetricViews create metric provider views from the view configs
+//
+//	views, err := otel.NewMetricViews([]otel.MetricViewConfig{{
+//		Instrument:  "http.server.duration",
+//		Aggregation: otel.ExplicitBucketHistogramMetricAggregation,
+//		Buckets:     []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1},
+//	}})
+//	otel.NewMetricProvider(res, reader, sdkmetric.WithView(views...))
+func NewMetricViews(configs []MetricViewConfig) ([]sdkmetric.View, error) {
+	views := make([]sdkmetric.View, 0, len(configs))
+
+	for i, config := range configs {
+		view, err := NewMetricView(config)
+		if err != nil {
+			return nil, fmt.Errorf("metric view %d: %w", i, err)
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// NewMetricView create metric provider view from the view config
+func NewMetricView(config MetricViewConfig) (sdkmetric.View, error) {
+	kind, err := config.Kind.instrumentKind()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation, err := config.aggregation()
+	if err != nil {
+		return nil, err
+	}
+
+	instrument := config.Instrument
+	if instrument == "" {
+		instrument = "*"
+	}
+
+	if config.Name != "" && strings.ContainsAny(instrument, "*?") {
+		return nil, ErrInvalidMetricViewName
+	}
+
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{
+			Name:  instrument,
+			Kind:  kind,
+			Scope: instrumentation.Scope{Name: config.Meter},
+		},
+		sdkmetric.Stream{
+			Name:            config.Name,
+			Description:     config.Description,
+			Aggregation:     aggregation,
+			AttributeFilter: config.attributeFilter(),
+		},
+	), nil
+}
+
+// ReadMetricViewsFile read metric view configs from json or yaml file, the file contains list of MetricViewConfig
+//
+//	[
+//		{"instrument": "http.server.*", "exclude_attribute_keys": ["http.user_agent"]},
+//		{"instrument": "db.client.duration", "aggregation": "explicit_bucket_histogram", "buckets": [0.001, 0.01, 0.1]}
+//	]
+func ReadMetricViewsFile(path string) ([]MetricViewConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []MetricViewConfig
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &configs)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &configs)
+	default:
+		return nil, ErrInvalidMetricViewsFile
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse metric views file %s: %w", path, err)
+	}
+
+	return configs, nil
+}
+
+func (k MetricInstrumentKind) instrumentKind() (sdkmetric.InstrumentKind, error) {
+	switch k {
+	case "":
+		return 0, nil
+	case CounterInstrumentKind:
+		return sdkmetric.InstrumentKindCounter, nil
+	case UpDownCounterInstrumentKind:
+		return sdkmetric.InstrumentKindUpDownCounter, nil
+	case HistogramInstrumentKind:
+		return sdkmetric.InstrumentKindHistogram, nil
+	case GaugeInstrumentKind:
+		return sdkmetric.InstrumentKindGauge, nil
+	case ObservableCounterInstrumentKind:
+		return sdkmetric.InstrumentKindObservableCounter, nil
+	case ObservableUpDownCounterInstrumentKind:
+		return sdkmetric.InstrumentKindObservableUpDownCounter, nil
+	case ObservableGaugeInstrumentKind:
+		return sdkmetric.InstrumentKindObservableGauge, nil
+	}
+
+	return 0, ErrInvalidMetricInstrumentKind
+}
+
+func (c MetricViewConfig) aggregation() (sdkmetric.Aggregation, error) {
+	if c.BucketPreset != "" {
+		if len(c.Buckets) > 0 || (c.Aggregation != "" && c.Aggregation != ExplicitBucketHistogramMetricAggregation) {
+			return nil, fmt.Errorf("%w: %q can only be used with explicit_bucket_histogram aggregation without buckets",
+				ErrInvalidHistogramBucketPreset, c.BucketPreset)
+		}
+
+		buckets, err := c.BucketPreset.Buckets()
+		if err != nil {
+			return nil, err
+		}
+
+		return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: buckets}, nil
+	}
+
+	switch c.Aggregation {
+	case "":
+		return nil, nil
+	case DefaultMetricAggregation:
+		return sdkmetric.AggregationDefault{}, nil
+	case DropMetricAggregation:
+		return sdkmetric.AggregationDrop{}, nil
+	case SumMetricAggregation:
+		return sdkmetric.AggregationSum{}, nil
+	case LastValueMetricAggregation:
+		return sdkmetric.AggregationLastValue{}, nil
+	case ExplicitBucketHistogramMetricAggregation:
+		for i := 1; i < len(c.Buckets); i++ {
+			if c.Buckets[i] <= c.Buckets[i-1] {
+				return nil, ErrInvalidMetricViewBuckets
+			}
+		}
+
+		return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: c.Buckets}, nil
+	case ExponentialHistogramMetricAggregation:
+		maxScale := int32(exponentialHistogramMaxScaleDefault)
+		if c.MaxScale != nil {
+			maxScale = *c.MaxScale
+		}
+
+		return NewExponentialHistogramAggregation(c.MaxSize, maxScale)
+	}
+
+	return nil, ErrInvalidMetricAggregationType
+}
+
+func (c MetricViewConfig) attributeFilter() attribute.Filter {
+	if len(c.AttributeKeys) == 0 && len(c.ExcludeAttributeKeys) == 0 {
+		return nil
+	}
+
+	allow := make(map[attribute.Key]bool, len(c.AttributeKeys))
+	for _, key := range c.AttributeKeys {
+		allow[attribute.Key(key)] = true
+	}
+
+	deny := make(map[attribute.Key]bool, len(c.ExcludeAttributeKeys))
+	for _, key := range c.ExcludeAttributeKeys {
+		deny[attribute.Key(key)] = true
+	}
+
+	return func(kv attribute.KeyValue) bool {
+		if len(allow) > 0 && !allow[kv.Key] {
+			return false
+		}
+
+		return !deny[kv.Key]
+	}
+}
@@ -0,0 +1,105 @@
+package otel
+
+import "errors"
+
+// MetricViewConfig view configuration for metric instrument
+// instrument is matched by Instrument name glob, Meter name and Kind, empty criteria match all
+type MetricViewConfig struct {
+	// Instrument instrument name, support "*" and "?" glob pattern
+	Instrument string `json:"instrument" yaml:"instrument"`
+	// Meter meter (instrumentation scope) name
+	Meter string `json:"meter" yaml:"meter"`
+	// Kind instrument kind, see MetricInstrumentKind
+	Kind MetricInstrumentKind `json:"kind" yaml:"kind"`
+
+	// Name new metric stream name, can't be used with glob instrument
+	Name string `json:"name" yaml:"name"`
+	// Description new metric stream description
+	Description string `json:"description" yaml:"description"`
+	// AttributeKeys attribute keys allowlist, other attribute will be dropped
+	AttributeKeys []string `json:"attribute_keys" yaml:"attribute_keys"`
+	// ExcludeAttributeKeys attribute keys denylist
+	ExcludeAttributeKeys []string `json:"exclude_attribute_keys" yaml:"exclude_attribute_keys"`
+	// Aggregation aggregation type, see MetricAggregationType
+	Aggregation MetricAggregationType `json:"aggregation" yaml:"aggregation"`
+	// Buckets explicit bucket histogram boundaries
+	Buckets []float64 `json:"buckets" yaml:"buckets"`
+	// BucketPreset named explicit bucket histogram boundaries, can't be used with Buckets, see HistogramBucketPreset
+	BucketPreset HistogramBucketPreset `json:"bucket_preset" yaml:"bucket_preset"`
+	// MaxSize maximum number of bucket for exponential histogram (default: 160)
+	MaxSize int32 `json:"max_size" yaml:"max_size"`
+	// MaxScale maximum scale for exponential histogram, nil use the default and 0 is valid scale (default: 20)
+	MaxScale *int32 `json:"max_scale" yaml:"max_scale"`
+	// SeriesLimit maximum attribute set per metric stream, the rest is folded into overflow series.
+	// 0 use the default limit and SeriesLimitDisabled disable the limit of the stream
+	SeriesLimit int `json:"series_limit" yaml:"series_limit"`
+}
+
+// MetricInstrumentKind instrument kind for view matching
+type MetricInstrumentKind string
+
+const (
+	// CounterInstrumentKind synchronous counter
+	CounterInstrumentKind MetricInstrumentKind = "counter"
+	// UpDownCounterInstrumentKind synchronous up down counter
+	UpDownCounterInstrumentKind MetricInstrumentKind = "up_down_counter"
+	// HistogramInstrumentKind synchronous histogram
+	HistogramInstrumentKind MetricInstrumentKind = "histogram"
+	// GaugeInstrumentKind synchronous gauge
+	GaugeInstrumentKind MetricInstrumentKind = "gauge"
+	// ObservableCounterInstrumentKind asynchronous counter
+	ObservableCounterInstrumentKind MetricInstrumentKind = "observable_counter"
+	// ObservableUpDownCounterInstrumentKind asynchronous up down counter
+	ObservableUpDownCounterInstrumentKind MetricInstrumentKind = "observable_up_down_counter"
+	// ObservableGaugeInstrumentKind asynchronous gauge
+	ObservableGaugeInstrumentKind MetricInstrumentKind = "observable_gauge"
+)
+
+// MetricAggregationType aggregation type for view
+type MetricAggregationType string
+
+const (
+	// DefaultMetricAggregation default aggregation of the instrument kind
+	DefaultMetricAggregation MetricAggregationType = "default"
+	// DropMetricAggregation drop all measurement
+	DropMetricAggregation MetricAggregationType = "drop"
+	// SumMetricAggregation sum aggregation
+	SumMetricAggregation MetricAggregationType = "sum"
+	// LastValueMetricAggregation last value aggregation
+	LastValueMetricAggregation MetricAggregationType = "last_value"
+	// ExplicitBucketHistogramMetricAggregation explicit bucket histogram aggregation
+	ExplicitBucketHistogramMetricAggregation MetricAggregationType = "explicit_bucket_histogram"
+	// ExponentialHistogramMetricAggregation base2 exponential bucket histogram aggregation
+	ExponentialHistogramMetricAggregation MetricAggregationType = "base2_exponential_bucket_histogram"
+)
+
+// HistogramBucketPreset named explicit bucket histogram boundaries
+type HistogramBucketPreset string
+
+const (
+	// RPCFastBucketPreset millisecond scale rpc duration in seconds, 0.5ms to 1s
+	RPCFastBucketPreset HistogramBucketPreset = "rpc_fast"
+	// HTTPDefaultBucketPreset http duration in seconds from otel semantic convention, 5ms to 10s
+	HTTPDefaultBucketPreset HistogramBucketPreset = "http_default"
+	// BatchSecondsBucketPreset batch job duration in seconds, 1s to 1h
+	BatchSecondsBucketPreset HistogramBucketPreset = "batch_seconds"
+	// BytesSizeBucketPreset payload size in bytes, 64B to 64MiB
+	BytesSizeBucketPreset HistogramBucketPreset = "bytes_size"
+)
+
+var (
+	// ErrInvalidMetricInstrumentKind invalid metric instrument kind error
+	ErrInvalidMetricInstrumentKind = errors.New("invalid metric instrument kind")
+	// ErrInvalidMetricAggregationType invalid metric aggregation type error
+	ErrInvalidMetricAggregationType = errors.New("invalid metric aggregation type")
+	// ErrInvalidMetricViewName view name can't be used with glob instrument error
+	ErrInvalidMetricViewName = errors.New("metric view name can't be used with glob instrument")
+	// ErrInvalidMetricViewBuckets explicit bucket boundaries not increasing error
+	ErrInvalidMetricViewBuckets = errors.New("metric view buckets must be increasing")
+	// ErrInvalidHistogramBucketPreset unknown histogram bucket preset or preset used with buckets error
+	ErrInvalidHistogramBucketPreset = errors.New("invalid histogram bucket preset")
+	// ErrInvalidExponentialHistogram exponential histogram max size or max scale out of range error
+	ErrInvalidExponentialHistogram = errors.New("invalid exponential histogram, max size must be positive and max scale between -10 and 20")
+	// ErrInvalidMetricViewsFile invalid metric views file extension error
+	ErrInvalidMetricViewsFile = errors.New("invalid metric views file, supported extension .json, .yaml and .yml")
+)
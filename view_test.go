@@ -0,0 +1,359 @@
+package otel
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewMetricViewMatch(t *testing.T) {
+	var (
+		histogram = sdkmetric.Instrument{Name: "http.server.duration", Kind: sdkmetric.InstrumentKindHistogram,
+			Scope: instrumentation.Scope{Name: "otelhttp"}}
+		counter = sdkmetric.Instrument{Name: "http.server.requests", Kind: sdkmetric.InstrumentKindCounter,
+			Scope: instrumentation.Scope{Name: "otelhttp"}}
+		other = sdkmetric.Instrument{Name: "db.client.duration", Kind: sdkmetric.InstrumentKindHistogram,
+			Scope: instrumentation.Scope{Name: "otelsql"}}
+	)
+
+	tests := []struct {
+		name     string
+		config   MetricViewConfig
+		match    []sdkmetric.Instrument
+		notMatch []sdkmetric.Instrument
+		wantName string
+	}{
+		{
+			name:   "empty criteria match all",
+			config: MetricViewConfig{Description: "all"},
+			match:  []sdkmetric.Instrument{histogram, counter, other},
+		},
+		{
+			name:     "exact name",
+			config:   MetricViewConfig{Instrument: "http.server.duration"},
+			match:    []sdkmetric.Instrument{histogram},
+			notMatch: []sdkmetric.Instrument{counter, other},
+		},
+		{
+			name:     "star glob",
+			config:   MetricViewConfig{Instrument: "http.server.*"},
+			match:    []sdkmetric.Instrument{histogram, counter},
+			notMatch: []sdkmetric.Instrument{other},
+		},
+		{
+			name:     "question mark glob",
+			config:   MetricViewConfig{Instrument: "??.client.duration"},
+			match:    []sdkmetric.Instrument{other},
+			notMatch: []sdkmetric.Instrument{histogram, counter},
+		},
+		{
+			name:     "meter",
+			config:   MetricViewConfig{Meter: "otelsql"},
+			match:    []sdkmetric.Instrument{other},
+			notMatch: []sdkmetric.Instrument{histogram, counter},
+		},
+		{
+			name:     "kind",
+			config:   MetricViewConfig{Kind: HistogramInstrumentKind},
+			match:    []sdkmetric.Instrument{histogram, other},
+			notMatch: []sdkmetric.Instrument{counter},
+		},
+		{
+			name:     "glob, meter and kind",
+			config:   MetricViewConfig{Instrument: "*.duration", Meter: "otelhttp", Kind: HistogramInstrumentKind},
+			match:    []sdkmetric.Instrument{histogram},
+			notMatch: []sdkmetric.Instrument{counter, other},
+		},
+		{
+			name:     "rename",
+			config:   MetricViewConfig{Instrument: "http.server.duration", Name: "http.server.request.duration"},
+			match:    []sdkmetric.Instrument{histogram},
+			notMatch: []sdkmetric.Instrument{counter},
+			wantName: "http.server.request.duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := NewMetricView(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, instrument := range tt.match {
+				stream, ok := view(instrument)
+				if !ok {
+					t.Errorf("got %s not matched, want matched", instrument.Name)
+					continue
+				}
+
+				if tt.wantName != "" && stream.Name != tt.wantName {
+					t.Errorf("got stream name %q, want %q", stream.Name, tt.wantName)
+				}
+
+				if tt.config.Description != "" && stream.Description != tt.config.Description {
+					t.Errorf("got stream description %q, want %q", stream.Description, tt.config.Description)
+				}
+			}
+
+			for _, instrument := range tt.notMatch {
+				if _, ok := view(instrument); ok {
+					t.Errorf("got %s matched, want not matched", instrument.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMetricViewAttributeFilter(t *testing.T) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.route", "/users"),
+		attribute.String("http.user_agent", "curl"),
+	}
+
+	tests := []struct {
+		name   string
+		config MetricViewConfig
+		want   []attribute.Key
+	}{
+		{
+			name: "no filter",
+			want: []attribute.Key{"http.method", "http.route", "http.user_agent"},
+		},
+		{
+			name:   "allow",
+			config: MetricViewConfig{AttributeKeys: []string{"http.method", "http.route"}},
+			want:   []attribute.Key{"http.method", "http.route"},
+		},
+		{
+			name:   "deny",
+			config: MetricViewConfig{ExcludeAttributeKeys: []string{"http.user_agent"}},
+			want:   []attribute.Key{"http.method", "http.route"},
+		},
+		{
+			name: "allow and deny",
+			config: MetricViewConfig{
+				AttributeKeys:        []string{"http.method", "http.route"},
+				ExcludeAttributeKeys: []string{"http.route"},
+			},
+			want: []attribute.Key{"http.method"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := NewMetricView(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream, ok := view(sdkmetric.Instrument{Name: "http.server.duration"})
+			if !ok {
+				t.Fatal("got instrument not matched")
+			}
+
+			var got []attribute.Key
+			for _, attr := range attrs {
+				if stream.AttributeFilter == nil || stream.AttributeFilter(attr) {
+					got = append(got, attr.Key)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got attributes %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMetricViewInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config MetricViewConfig
+		want   error
+	}{
+		{name: "kind", config: MetricViewConfig{Kind: "timer"}, want: ErrInvalidMetricInstrumentKind},
+		{name: "aggregation", config: MetricViewConfig{Aggregation: "summary"}, want: ErrInvalidMetricAggregationType},
+		{name: "rename glob", config: MetricViewConfig{Instrument: "http.*", Name: "http"}, want: ErrInvalidMetricViewName},
+		{name: "rename all", config: MetricViewConfig{Name: "http"}, want: ErrInvalidMetricViewName},
+		{name: "buckets not increasing", config: MetricViewConfig{
+			Aggregation: ExplicitBucketHistogramMetricAggregation, Buckets: []float64{1, 1},
+		}, want: ErrInvalidMetricViewBuckets},
+		{name: "unknown preset", config: MetricViewConfig{BucketPreset: "slow"}, want: ErrInvalidHistogramBucketPreset},
+		{name: "preset with buckets", config: MetricViewConfig{
+			BucketPreset: RPCFastBucketPreset, Buckets: []float64{1},
+		}, want: ErrInvalidHistogramBucketPreset},
+		{name: "exponential max size", config: MetricViewConfig{
+			Aggregation: ExponentialHistogramMetricAggregation, MaxSize: -1,
+		}, want: ErrInvalidExponentialHistogram},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMetricView(tt.config); !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewMetricViews([]MetricViewConfig{{}, {Kind: "timer"}}); !errors.Is(err, ErrInvalidMetricInstrumentKind) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidMetricInstrumentKind)
+	}
+}
+
+func TestReadMetricViewsFile(t *testing.T) {
+	want := []MetricViewConfig{
+		{Instrument: "http.server.*", ExcludeAttributeKeys: []string{"http.user_agent"}},
+		{Instrument: "db.client.duration", Aggregation: ExplicitBucketHistogramMetricAggregation, Buckets: []float64{0.001, 0.01}},
+		{Meter: "otelsql", Kind: CounterInstrumentKind, Name: "db.calls", SeriesLimit: SeriesLimitDisabled},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []MetricViewConfig
+		wantErr error
+	}{
+		{
+			name: "json",
+			file: "views.json",
+			content: `[
+				{"instrument": "http.server.*", "exclude_attribute_keys": ["http.user_agent"]},
+				{"instrument": "db.client.duration", "aggregation": "explicit_bucket_histogram", "buckets": [0.001, 0.01]},
+				{"meter": "otelsql", "kind": "counter", "name": "db.calls", "series_limit": -1}
+			]`,
+			want: want,
+		},
+		{
+			name: "yaml",
+			file: "views.yaml",
+			content: `
+- instrument: http.server.*
+  exclude_attribute_keys: [http.user_agent]
+- instrument: db.client.duration
+  aggregation: explicit_bucket_histogram
+  buckets: [0.001, 0.01]
+- meter: otelsql
+  kind: counter
+  name: db.calls
+  series_limit: -1
+`,
+			want: want,
+		},
+		{
+			name:    "yml uppercase extension",
+			file:    "views.YML",
+			content: "- instrument: http.server.*\n  exclude_attribute_keys: [http.user_agent]",
+			want:    want[:1],
+		},
+		{name: "unsupported extension", file: "views.toml", content: "", wantErr: ErrInvalidMetricViewsFile},
+		{name: "invalid json", file: "views.json", content: `{"instrument": "http.*"}`},
+		{name: "invalid yaml", file: "views.yaml", content: "- instrument: [http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadMetricViewsFile(path)
+			if tt.want == nil {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("got error %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ReadMetricViewsFile(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want not exist", err)
+	}
+}
+
+func TestMetricViewConfigExponentialHistogramScale(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want int32
+	}{
+		{name: "unset", yaml: "aggregation: base2_exponential_bucket_histogram", want: exponentialHistogramMaxScaleDefault},
+		{name: "zero", yaml: "aggregation: base2_exponential_bucket_histogram\nmax_scale: 0", want: 0},
+		{name: "negative", yaml: "aggregation: base2_exponential_bucket_histogram\nmax_scale: -5", want: -5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config MetricViewConfig
+			if err := yaml.Unmarshal([]byte(tt.yaml), &config); err != nil {
+				t.Fatal(err)
+			}
+
+			aggregation, err := config.aggregation()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := aggregation.(sdkmetric.AggregationBase2ExponentialHistogram).MaxScale; got != tt.want {
+				t.Errorf("got max scale %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetExponentialHistogramAggregationFromEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxSize      string
+		maxScale     string
+		wantMaxSize  int32
+		wantMaxScale int32
+		wantErr      bool
+	}{
+		{name: "zero scale", maxScale: "0", wantMaxSize: exponentialHistogramMaxSizeDefault, wantMaxScale: 0},
+		{name: "unset scale", maxSize: "80", wantMaxSize: 80, wantMaxScale: exponentialHistogramMaxScaleDefault},
+		{name: "out of range scale", maxScale: "21", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(histogramAggregationEnv, string(ExponentialHistogramMetricAggregation))
+			t.Setenv(exponentialHistogramMaxSizeEnv, tt.maxSize)
+			t.Setenv(exponentialHistogramMaxScaleEnv, tt.maxScale)
+
+			aggregation, err := getExponentialHistogramAggregationFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := aggregation.(sdkmetric.AggregationBase2ExponentialHistogram)
+			if got.MaxSize != tt.wantMaxSize || got.MaxScale != tt.wantMaxScale {
+				t.Errorf("got max size %d max scale %d, want %d %d", got.MaxSize, got.MaxScale, tt.wantMaxSize, tt.wantMaxScale)
+			}
+		})
+	}
+}
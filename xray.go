@@ -0,0 +1,165 @@
+package otel
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// x-ray trace header format
+// X-Amzn-Trace-Id: Root=1-{8 hex epoch}-{24 hex unique};Parent={16 hex span id};Sampled={0|1}
+const (
+	xrayTraceHeader      = "X-Amzn-Trace-Id"
+	xrayRootKey          = "Root"
+	xrayParentKey        = "Parent"
+	xraySampledKey       = "Sampled"
+	xrayTraceIDVersion   = "1"
+	xrayTraceIDLength    = 35
+	xrayTraceIDEpochSize = 8
+)
+
+var (
+	_ sdktrace.IDGenerator          = (*XRayIDGenerator)(nil)
+	_ propagation.TextMapPropagator = XRayPropagator{}
+)
+
+// XRayIDGenerator id generator that generate AWS X-Ray compatible trace id
+// the first 4 bytes of the trace id is the start time in unix epoch seconds
+type XRayIDGenerator struct{}
+
+// NewXRayIDGenerator create X-Ray compatible id generator
+func NewXRayIDGenerator() *XRayIDGenerator {
+	return &XRayIDGenerator{}
+}
+
+// NewIDs returns new time prefixed trace id and random span id
+func (g *XRayIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	var traceID trace.TraceID
+
+	binary.BigEndian.PutUint32(traceID[:4], uint32(time.Now().Unix()))
+	binary.BigEndian.PutUint32(traceID[4:8], rand.Uint32())
+	binary.BigEndian.PutUint64(traceID[8:], rand.Uint64())
+
+	return traceID, g.NewSpanID(ctx, traceID)
+}
+
+// NewSpanID returns new random span id
+func (g *XRayIDGenerator) NewSpanID(_ context.Context, _ trace.TraceID) trace.SpanID {
+	var spanID trace.SpanID
+
+	for !spanID.IsValid() {
+		binary.BigEndian.PutUint64(spanID[:], rand.Uint64())
+	}
+
+	return spanID
+}
+
+// XRayPropagator propagator for AWS X-Ray X-Amzn-Trace-Id header
+type XRayPropagator struct{}
+
+// Inject set the span context from ctx to the carrier with X-Ray header format
+func (XRayPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.IsValid() {
+		return
+	}
+
+	traceID := spanContext.TraceID().String()
+	sampled := "0"
+	if spanContext.IsSampled() {
+		sampled = "1"
+	}
+
+	carrier.Set(xrayTraceHeader, xrayRootKey+"="+xrayTraceIDVersion+"-"+traceID[:xrayTraceIDEpochSize]+"-"+traceID[xrayTraceIDEpochSize:]+
+		";"+xrayParentKey+"="+spanContext.SpanID().String()+
+		";"+xraySampledKey+"="+sampled)
+}
+
+// Extract read X-Ray header from the carrier and returns ctx with the remote span context
+// invalid header will be ignored, empty part such as trailing ";" is skipped.
+// header with Root but without Parent (e.g. added by ALB) keep the trace id with random parent span id
+func (XRayPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	header := carrier.Get(xrayTraceHeader)
+	if header == "" {
+		return ctx
+	}
+
+	var config trace.SpanContextConfig
+
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return ctx
+		}
+
+		switch key {
+		case xrayRootKey:
+			traceID, ok := parseXRayTraceID(value)
+			if !ok {
+				return ctx
+			}
+
+			config.TraceID = traceID
+		case xrayParentKey:
+			spanID, err := trace.SpanIDFromHex(value)
+			if err != nil {
+				return ctx
+			}
+
+			config.SpanID = spanID
+		case xraySampledKey:
+			if value == "1" {
+				config.TraceFlags = trace.FlagsSampled
+			}
+		}
+	}
+
+	if config.TraceID.IsValid() && !config.SpanID.IsValid() {
+		config.SpanID = (&XRayIDGenerator{}).NewSpanID(ctx, config.TraceID)
+	}
+
+	config.Remote = true
+	spanContext := trace.NewSpanContext(config)
+	if !spanContext.IsValid() {
+		return ctx
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+}
+
+// Fields returns the header used by the propagator
+func (XRayPropagator) Fields() []string {
+	return []string{xrayTraceHeader}
+}
+
+// parseXRayTraceID parse X-Ray trace id 1-{8 hex epoch}-{24 hex unique} to trace id
+func parseXRayTraceID(value string) (trace.TraceID, bool) {
+	if len(value) != xrayTraceIDLength {
+		return trace.TraceID{}, false
+	}
+
+	version, rest, _ := strings.Cut(value, "-")
+	epoch, unique, ok := strings.Cut(rest, "-")
+	if version != xrayTraceIDVersion || !ok || len(epoch) != xrayTraceIDEpochSize {
+		return trace.TraceID{}, false
+	}
+
+	var traceID trace.TraceID
+	if _, err := hex.Decode(traceID[:], []byte(epoch+unique)); err != nil {
+		return trace.TraceID{}, false
+	}
+
+	return traceID, traceID.IsValid()
+}
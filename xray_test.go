@@ -0,0 +1,107 @@
+package otel
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestXRayPropagatorExtract(t *testing.T) {
+	wantTraceID, _ := trace.TraceIDFromHex("5759e988bd862e3fe1be46a994272793")
+	wantSpanID, _ := trace.SpanIDFromHex("53995c3f42cd8ad8")
+
+	tests := []struct {
+		name     string
+		header   string
+		valid    bool
+		sampled  bool
+		noParent bool
+	}{
+		{name: "sampled", header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1", valid: true, sampled: true},
+		{name: "trailing separator", header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=0;", valid: true},
+		{name: "empty part and space", header: " Root=1-5759e988-bd862e3fe1be46a994272793; ;Parent=53995c3f42cd8ad8 ", valid: true},
+		{name: "lineage", header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=a87bd80c:1|68fd508a:5", valid: true, sampled: true},
+		{name: "part without value", header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent;Sampled=1"},
+		{name: "invalid root", header: "Root=2-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8"},
+		{name: "missing parent", header: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", valid: true, sampled: true, noParent: true},
+		{name: "missing root", header: "Parent=53995c3f42cd8ad8;Sampled=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{xrayTraceHeader: tt.header}
+			spanContext := trace.SpanContextFromContext(XRayPropagator{}.Extract(context.Background(), carrier))
+
+			if spanContext.IsValid() != tt.valid {
+				t.Fatalf("got valid %v, want %v", spanContext.IsValid(), tt.valid)
+			}
+
+			if !tt.valid {
+				return
+			}
+
+			// random parent span id is generated when the header has no parent
+			if spanContext.TraceID() != wantTraceID || (spanContext.SpanID() == wantSpanID) == tt.noParent ||
+				spanContext.IsSampled() != tt.sampled || !spanContext.IsRemote() {
+				t.Errorf("got span context %+v", spanContext)
+			}
+		})
+	}
+}
+
+func TestXRayPropagatorInject(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("5759e988bd862e3fe1be46a994272793")
+	spanID, _ := trace.SpanIDFromHex("53995c3f42cd8ad8")
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	carrier := propagation.MapCarrier{}
+	XRayPropagator{}.Inject(ctx, carrier)
+
+	if want := "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"; carrier.Get(xrayTraceHeader) != want {
+		t.Errorf("got header %q, want %q", carrier.Get(xrayTraceHeader), want)
+	}
+}
+
+func TestXRayIDGenerator(t *testing.T) {
+	generator := NewXRayIDGenerator()
+
+	before := time.Now().Unix()
+	traceID, spanID := generator.NewIDs(context.Background())
+	after := time.Now().Unix()
+
+	if !traceID.IsValid() || !spanID.IsValid() {
+		t.Fatalf("got invalid trace id %s or span id %s", traceID, spanID)
+	}
+
+	// the first 4 bytes is the epoch, X-Ray reject trace id older than 30 days
+	if epoch := int64(binary.BigEndian.Uint32(traceID[:4])); epoch < before || epoch > after {
+		t.Errorf("got trace id epoch %d, want between %d and %d", epoch, before, after)
+	}
+
+	otherTraceID, otherSpanID := generator.NewIDs(context.Background())
+	if otherTraceID == traceID || otherSpanID == spanID {
+		t.Error("got same id, want random id")
+	}
+
+	// generated trace id round trip through the X-Ray header
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  generator.NewSpanID(context.Background(), traceID),
+	}))
+
+	carrier := propagation.MapCarrier{}
+	XRayPropagator{}.Inject(ctx, carrier)
+
+	if got := trace.SpanContextFromContext(XRayPropagator{}.Extract(context.Background(), carrier)); got.TraceID() != traceID {
+		t.Errorf("got trace id %s from header %q, want %s", got.TraceID(), carrier.Get(xrayTraceHeader), traceID)
+	}
+}